@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/config"
+	"github.com/AnruKitakaze/commit-msg-guardian/git"
+	"github.com/AnruKitakaze/commit-msg-guardian/parser"
+)
+
+// checker validates commit messages with the configured rules and limits.
+type checker struct {
+	config     *config.Config
+	rules      config.RuleSet
+	limits     parser.LengthLimits
+	references parser.ReferenceRequirement
+	options    parser.ParseOptions
+}
+
+// rulesFor returns the rules for msg, with the branch profile applied and the
+// rules for the commit type and the matching conditions added. paths returns
+// the files the commit changes; it is only called when a condition needs
+// them, and git errors leave the list empty.
+func (c *checker) rulesFor(msg *parser.CommitMessage, paths func() ([]string, error)) config.RuleSet {
+	return c.config.RulesFor(c.rules, msg, sync.OnceValue(func() []string {
+		changed, _ := paths()
+		return changed
+	}))
+}
+
+// validate validates msg with ruleSet and the configured limits and
+// reference requirement.
+func (c *checker) validate(msg *parser.CommitMessage, ruleSet config.RuleSet) error {
+	if err := msg.ValidateWithRules(ruleSet.Type, ruleSet.Scope, ruleSet.Description, ruleSet.Body); err != nil {
+		return err
+	}
+	if err := msg.ValidateMessageWithRules(ruleSet.Message); err != nil {
+		return err
+	}
+	if err := msg.ValidateLengths(c.limits); err != nil {
+		return err
+	}
+	return msg.ValidateReferences(c.references)
+}
+
+// checkRange validates the message of every commit in revisionRange as if it
+// were committed on branch, and reports each invalid commit. It returns true
+// when any commit failed.
+func checkRange(revisionRange, branch string, c *checker) (bool, error) {
+	commits, err := git.Commits(revisionRange)
+	if err != nil {
+		return false, err
+	}
+	failed := 0
+	for _, commit := range commits {
+		err := c.checkCommit(commit, branch)
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "Commit %s: %v\n", shortHash(commit.Hash), err)
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d commits failed validation\n", failed, len(commits))
+	}
+	return failed > 0, nil
+}
+
+func (c *checker) checkCommit(commit git.Commit, branch string) error {
+	msg, err := parser.ParseCommitMessageWithOptions(commit.Message, c.options)
+	if err != nil {
+		return fmt.Errorf("parsing failed: %w", err)
+	}
+	msg.Branch = branch
+	msg.Author = commit.Author
+	ruleSet := c.rulesFor(msg, func() ([]string, error) { return git.CommitPaths(commit.Hash) })
+	if err := c.validate(msg, ruleSet); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
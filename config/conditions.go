@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// StringList is a list of strings. In YAML it is a sequence or a single
+// string.
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = StringList{node.Value}
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// Condition selects commits. Every field that is set must match; a list
+// matches when any of its items does, and an empty condition matches every
+// commit.
+type Condition struct {
+	// Type lists commit types.
+	Type StringList `yaml:"type"`
+	// Breaking matches breaking changes when true and other commits when
+	// false.
+	Breaking *bool `yaml:"breaking"`
+	// Scope lists scope glob patterns such as "api/*". Commits without a
+	// scope never match.
+	Scope StringList `yaml:"scope"`
+	// Branch lists branch glob patterns such as "release/*". Commits made on
+	// a detached HEAD never match.
+	Branch StringList `yaml:"branch"`
+	// Paths lists path glob patterns such as "db/migrations/**" that match
+	// when any staged file matches. A pattern without a slash, such as
+	// "*.sql", matches the file name in any directory; "/go.mod" matches
+	// only at the repository root.
+	Paths StringList `yaml:"paths"`
+}
+
+// ConditionalRules are rules added to the commits selected by When.
+type ConditionalRules struct {
+	When    Condition `yaml:"when"`
+	RuleSet `yaml:",inline"`
+}
+
+// BranchProfile is a rule profile for the branches matching Branch, such as
+// stricter rules for release branches or relaxed ones for sandboxes.
+type BranchProfile struct {
+	// Branch lists branch glob patterns such as "release/*".
+	Branch StringList `yaml:"branch"`
+	// Rules replace the rules of the parts they list, like the rules
+	// section of the configuration file.
+	Rules   RuleSet `yaml:"rules"`
+	RuleSet `yaml:",inline"`
+}
+
+// Matches reports whether the profile applies on branch. No profile applies
+// on a detached HEAD.
+func (p BranchProfile) Matches(branch string) bool {
+	return branch != "" && matchAny(p.Branch, branch)
+}
+
+// validate reports malformed glob patterns.
+func (c Condition) validate() error {
+	for _, pattern := range slices.Concat(c.Scope, c.Branch, c.Paths) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the commit is selected. stagedPaths is only called
+// when the condition has path patterns.
+func (c Condition) Matches(msg *parser.CommitMessage, stagedPaths func() []string) bool {
+	if len(c.Type) > 0 && !slices.ContainsFunc(c.Type, func(t string) bool { return strings.EqualFold(t, msg.Type) }) {
+		return false
+	}
+	if c.Breaking != nil && *c.Breaking != msg.BreakingChange {
+		return false
+	}
+	if len(c.Scope) > 0 && (msg.Scope == "" || !matchAny(c.Scope, msg.Scope)) {
+		return false
+	}
+	if len(c.Branch) > 0 && (msg.Branch == "" || !matchAny(c.Branch, msg.Branch)) {
+		return false
+	}
+	if len(c.Paths) > 0 && !slices.ContainsFunc(stagedPaths(), func(name string) bool {
+		return slices.ContainsFunc(c.Paths, func(pattern string) bool { return matchPath(pattern, name) })
+	}) {
+		return false
+	}
+	return true
+}
+
+func matchAny(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		matched, _ := path.Match(pattern, name)
+		return matched
+	})
+}
+
+// matchPath matches a slash-separated file name against a glob pattern in
+// which a "**" segment matches any number of directories. A leading slash
+// anchors a pattern without other slashes to the repository root.
+func matchPath(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		name = path.Base(name)
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(patterns, names []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := len(names); i >= 0; i-- {
+				if matchSegments(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(patterns[0], names[0]); !matched {
+			return false
+		}
+		patterns, names = patterns[1:], names[1:]
+	}
+	return len(names) == 0
+}
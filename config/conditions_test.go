@@ -0,0 +1,236 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/parser"
+)
+
+func TestConditionMatches(t *testing.T) {
+	yes, no := true, false
+	msg := &parser.CommitMessage{Type: "feat", Scope: "api/users", BreakingChange: true, Branch: "release/1.2"}
+	staged := func() []string { return []string{"README.md", "db/migrations/001_init.sql"} }
+	tests := []struct {
+		name      string
+		condition Condition
+		want      bool
+	}{
+		{"empty condition", Condition{}, true},
+		{"type", Condition{Type: StringList{"fix", "feat"}}, true},
+		{"other type", Condition{Type: StringList{"fix"}}, false},
+		{"type in another case", Condition{Type: StringList{"FEAT"}}, true},
+		{"breaking", Condition{Breaking: &yes}, true},
+		{"not breaking", Condition{Breaking: &no}, false},
+		{"scope glob", Condition{Scope: StringList{"api/*"}}, true},
+		{"scope glob does not cross slashes", Condition{Scope: StringList{"*"}}, false},
+		{"branch glob", Condition{Branch: StringList{"main", "release/*"}}, true},
+		{"other branch", Condition{Branch: StringList{"main"}}, false},
+		{"staged path", Condition{Paths: StringList{"db/migrations/**"}}, true},
+		{"staged file name", Condition{Paths: StringList{"*.sql"}}, true},
+		{"no staged path", Condition{Paths: StringList{"docs/**"}}, false},
+		{"all fields", Condition{Type: StringList{"feat"}, Breaking: &yes, Scope: StringList{"api/*"}, Branch: StringList{"release/*"}, Paths: StringList{"*.md"}}, true},
+		{"one field does not match", Condition{Type: StringList{"feat"}, Branch: StringList{"main"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.condition.Matches(msg, staged); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionMatchesWithoutContext(t *testing.T) {
+	msg := &parser.CommitMessage{Type: "fix"}
+	called := false
+	staged := func() []string { called = true; return nil }
+
+	if (Condition{Scope: StringList{"*"}}).Matches(msg, staged) {
+		t.Error("Matches() of a scope pattern without a scope = true, want false")
+	}
+	if (Condition{Branch: StringList{"*"}}).Matches(msg, staged) {
+		t.Error("Matches() of a branch pattern on a detached HEAD = true, want false")
+	}
+	if called {
+		t.Error("Matches() read staged paths without path patterns")
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"db/migrations/**", "db/migrations/001_init.sql", true},
+		{"db/migrations/**", "db/migrations/2024/001_init.sql", true},
+		{"db/migrations/**", "db/seeds/users.sql", false},
+		{"**/package.json", "package.json", true},
+		{"**/package.json", "web/app/package.json", true},
+		{"src/**/*_test.go", "src/a/b/rules_test.go", true},
+		{"src/*.go", "src/a/rules.go", false},
+		{"*.sql", "db/migrations/001_init.sql", true},
+		{"*.sql", "README.md", false},
+		{"go.mod", "go.mod", true},
+		{"go.mod", "tools/go.mod", true},
+		{"/go.mod", "go.mod", true},
+		{"/go.mod", "tools/go.mod", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			if got := matchPath(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("matchPath(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesForConditional(t *testing.T) {
+	data := `
+types:
+  feat:
+    body: [notEmpty]
+conditional:
+  - when:
+      breaking: true
+    body: [minWords(10)]
+  - when:
+      scope: api/*
+      branch: [main, release/*]
+    message: [signedOff]
+  - when:
+      paths: db/migrations/**
+    description: denyWords(wip)
+`
+	cfg, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	staged := func() []string { return []string{"db/migrations/001_init.sql"} }
+
+	msg := &parser.CommitMessage{Type: "feat", Scope: "api/users", BreakingChange: true, Branch: "main"}
+	got := cfg.RulesFor(RuleSet{}, msg, staged)
+	if !slices.Equal(got.Body, []string{"notEmpty", "minWords(10)"}) {
+		t.Errorf("RulesFor().Body = %q", got.Body)
+	}
+	if !slices.Equal(got.Message, []string{"signedOff"}) {
+		t.Errorf("RulesFor().Message = %q", got.Message)
+	}
+	if !slices.Equal(got.Description, []string{"denyWords(wip)"}) {
+		t.Errorf("RulesFor().Description = %q", got.Description)
+	}
+
+	// Types are matched ignoring case, as the parser accepts them.
+	got = cfg.RulesFor(RuleSet{}, &parser.CommitMessage{Type: "Feat", Branch: "sandbox/try"}, func() []string { return nil })
+	if !slices.Equal(got.Body, []string{"notEmpty"}) {
+		t.Errorf("RulesFor(Feat).Body = %q, want the feat rules", got.Body)
+	}
+
+	got = cfg.RulesFor(RuleSet{}, &parser.CommitMessage{Type: "docs", Branch: "sandbox/try"}, func() []string { return nil })
+	if len(got.Body)+len(got.Message)+len(got.Description) != 0 {
+		t.Errorf("RulesFor() of an unmatched commit = %q, want no rules", got)
+	}
+}
+
+func TestParseConditionalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"unknown condition", "conditional:\n  - when:\n      author: me\n    body: [notEmpty]\n"},
+		{"unknown commit type", "conditional:\n  - when:\n      type: feature\n    body: [notEmpty]\n"},
+		{"invalid pattern", "conditional:\n  - when:\n      branch: \"release/[\"\n    body: [notEmpty]\n"},
+		{"breaking is not a boolean", "conditional:\n  - when:\n      breaking: sometimes\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Errorf("Parse(%q) error = nil, want error", tt.data)
+			}
+		})
+	}
+}
+
+func TestRulesForBranchProfiles(t *testing.T) {
+	data := `
+types:
+  feat:
+    body: [notEmpty]
+branches:
+  - branch: release/*
+    description: [imperative]
+  - branch: [sandbox/*, experiment/*]
+    rules:
+      description: []
+      body: []
+  - branch: main
+    message: [noFixup]
+  - branch: "*"
+    message: [signedOff]
+`
+	cfg, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	base := RuleSet{Type: RuleList{"allowLatin"}, Description: RuleList{"noCyrillic"}, Body: RuleList{"spelling"}}
+
+	tests := []struct {
+		name   string
+		branch string
+		want   RuleSet
+	}{
+		{"stricter", "release/1.2", RuleSet{Type: RuleList{"allowLatin"}, Description: RuleList{"noCyrillic", "imperative"}, Body: RuleList{"spelling", "notEmpty"}}},
+		{"relaxed", "sandbox/try", RuleSet{Type: RuleList{"allowLatin"}, Body: RuleList{"notEmpty"}}},
+		{"first match only", "main", RuleSet{Type: RuleList{"allowLatin"}, Description: RuleList{"noCyrillic"}, Body: RuleList{"spelling", "notEmpty"}, Message: RuleList{"noFixup"}}},
+		{"catch-all", "develop", RuleSet{Type: RuleList{"allowLatin"}, Description: RuleList{"noCyrillic"}, Body: RuleList{"spelling", "notEmpty"}, Message: RuleList{"signedOff"}}},
+		{"detached HEAD", "", RuleSet{Type: RuleList{"allowLatin"}, Description: RuleList{"noCyrillic"}, Body: RuleList{"spelling", "notEmpty"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &parser.CommitMessage{Type: "feat", Branch: tt.branch}
+			got := cfg.RulesFor(base, msg, func() []string { return nil })
+			for _, part := range []struct {
+				name      string
+				got, want RuleList
+			}{
+				{"Type", got.Type, tt.want.Type},
+				{"Scope", got.Scope, tt.want.Scope},
+				{"Description", got.Description, tt.want.Description},
+				{"Body", got.Body, tt.want.Body},
+				{"Message", got.Message, tt.want.Message},
+			} {
+				if !slices.Equal(part.got, part.want) {
+					t.Errorf("RulesFor().%s = %q, want %q", part.name, part.got, part.want)
+				}
+			}
+		})
+	}
+
+	if base.Description[0] != "noCyrillic" || len(base.Description) != 1 {
+		t.Errorf("RulesFor() modified the base rules: %q", base.Description)
+	}
+}
+
+func TestParseBranchProfileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"missing branch", "branches:\n  - message: [noFixup]\n"},
+		{"invalid pattern", "branches:\n  - branch: \"release/[\"\n"},
+		{"unknown key", "branches:\n  - branch: main\n    strict: true\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Errorf("Parse(%q) error = nil, want error", tt.data)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Package config reads the commit-msg-guardian configuration file.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/parser"
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the configuration file used when no other file is given.
+const FileName = ".commit-msg-guardian.yaml"
+
+// RuleList is a list of rule specifications. In YAML it is a sequence or a
+// comma-separated string; commas inside parentheses separate the arguments of
+// a parameterized rule in both forms, so that a flow sequence such as
+// [allowScripts(Latin, Greek)] holds a single rule.
+type RuleList []string
+
+func (l *RuleList) UnmarshalYAML(node *yaml.Node) error {
+	var items []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		items = []string{node.Value}
+	case yaml.SequenceNode:
+		if err := node.Decode(&items); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("line %d: rules must be a list or a comma-separated string", node.Line)
+	}
+	// An empty list is kept non-nil, so that it can replace a default.
+	*l = append(RuleList{}, SplitRules(strings.Join(items, ", "))...)
+	return nil
+}
+
+// RuleSet lists the rules for each part of a commit message, like the
+// --type-rules, --scope-rules, --description-rules, --body-rules and
+// --message-rules options.
+type RuleSet struct {
+	Type        RuleList `yaml:"type"`
+	Scope       RuleList `yaml:"scope"`
+	Description RuleList `yaml:"description"`
+	Body        RuleList `yaml:"body"`
+	Message     RuleList `yaml:"message"`
+}
+
+// Merge returns the rules of s followed by the rules of other.
+func (s RuleSet) Merge(other RuleSet) RuleSet {
+	return RuleSet{
+		Type:        slices.Concat(s.Type, other.Type),
+		Scope:       slices.Concat(s.Scope, other.Scope),
+		Description: slices.Concat(s.Description, other.Description),
+		Body:        slices.Concat(s.Body, other.Body),
+		Message:     slices.Concat(s.Message, other.Message),
+	}
+}
+
+// Replace returns s with the parts listed in other replaced. A part is listed
+// when it is not nil, so that an empty list removes the rules of s.
+func (s RuleSet) Replace(other RuleSet) RuleSet {
+	replace := func(list, replacement RuleList) RuleList {
+		if replacement != nil {
+			return replacement
+		}
+		return list
+	}
+	return RuleSet{
+		Type:        replace(s.Type, other.Type),
+		Scope:       replace(s.Scope, other.Scope),
+		Description: replace(s.Description, other.Description),
+		Body:        replace(s.Body, other.Body),
+		Message:     replace(s.Message, other.Message),
+	}
+}
+
+// Config is the contents of a configuration file.
+type Config struct {
+	// Extends lists the presets this configuration builds on, in order. Each
+	// overrides the ones before it, and the configuration overrides them all.
+	Extends StringList `yaml:"extends"`
+	// Format is the header format: conventional, kernel or gitmoji.
+	Format string `yaml:"format"`
+	// CommitTypes lists the allowed commit types of conventional headers;
+	// nil allows rules.ConventionalCommitTypes.
+	CommitTypes StringList `yaml:"commitTypes"`
+	// GitmojiPrefix allows a gitmoji before the type of conventional
+	// headers; nil leaves the setting of the presets.
+	GitmojiPrefix *bool `yaml:"gitmojiPrefix"`
+	// Rules replace the defaults of the rule options that are not given on
+	// the command line. A part missing here keeps its default; an empty list
+	// removes it.
+	Rules RuleSet `yaml:"rules"`
+	// Types adds rules for commits of the given types to Rules. A key may
+	// list several comma-separated types, e.g. "feat, fix, perf".
+	Types map[string]RuleSet `yaml:"types"`
+	// Conditional adds rules for the commits matching each entry's when
+	// clause, after the rules of the commit type.
+	Conditional []ConditionalRules `yaml:"conditional"`
+	// Branches are rule profiles for branches. Only the first profile
+	// matching the branch applies.
+	Branches []BranchProfile `yaml:"branches"`
+}
+
+// Load reads the configuration file at path, extending presets first as
+// Parse does.
+func Load(path string, presets ...string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := Parse(data, presets...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse parses a configuration file that extends presets, followed by the
+// presets listed in its extends key. Unknown keys and commit types are
+// errors, so that misspelled settings are not silently ignored.
+func Parse(data []byte, presets ...string) (*Config, error) {
+	cfg, err := resolve(data, presets, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// resolve decodes a configuration and applies it over the presets it
+// extends. extending lists the presets being resolved, to detect cycles.
+func resolve(data []byte, presets, extending []string) (*Config, error) {
+	cfg, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &Config{}
+	for _, name := range slices.Concat(presets, cfg.Extends) {
+		if slices.Contains(extending, name) {
+			return nil, fmt.Errorf("preset %s extends itself", name)
+		}
+		presetData, err := presetFiles.ReadFile("presets/" + name + ".yaml")
+		if err != nil {
+			return nil, fmt.Errorf("unknown preset %q; available presets: %s", name, strings.Join(Presets(), ", "))
+		}
+		preset, err := resolve(presetData, nil, append(extending, name))
+		if err != nil {
+			return nil, fmt.Errorf("preset %s: %w", name, err)
+		}
+		resolved = resolved.Override(preset)
+	}
+	return resolved.Override(cfg), nil
+}
+
+// decode decodes a single configuration file and splits the keys of its
+// types section that list several types.
+func decode(data []byte) (*Config, error) {
+	cfg := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	// Keys are visited in sorted order, so that the rules a type gets from
+	// several keys are always merged in the same order.
+	types := map[string]RuleSet{}
+	for _, key := range slices.Sorted(maps.Keys(cfg.Types)) {
+		set := cfg.Types[key]
+		for _, commitType := range strings.Split(key, ",") {
+			commitType = strings.TrimSpace(commitType)
+			// Merging would lose an empty list that removes preset rules.
+			if existing, ok := types[commitType]; ok {
+				types[commitType] = existing.Merge(set)
+			} else {
+				types[commitType] = set
+			}
+		}
+	}
+	cfg.Types = types
+	return cfg, nil
+}
+
+// Override returns c with the settings of other applied over it. The format,
+// the commit types, the gitmoji prefix setting and the rule lists that other
+// sets replace those of c, per part and per commit type. The conditional
+// entries of both apply, and the branch profiles of other are matched before
+// those of c.
+func (c *Config) Override(other *Config) *Config {
+	result := &Config{
+		Format:        c.Format,
+		CommitTypes:   c.CommitTypes,
+		GitmojiPrefix: c.GitmojiPrefix,
+		Rules:         c.Rules.Replace(other.Rules),
+		Types:         map[string]RuleSet{},
+		Conditional:   slices.Concat(c.Conditional, other.Conditional),
+		Branches:      slices.Concat(other.Branches, c.Branches),
+	}
+	if other.Format != "" {
+		result.Format = other.Format
+	}
+	if other.CommitTypes != nil {
+		result.CommitTypes = other.CommitTypes
+	}
+	if other.GitmojiPrefix != nil {
+		result.GitmojiPrefix = other.GitmojiPrefix
+	}
+	for commitType, set := range c.Types {
+		result.Types[commitType] = set
+	}
+	for commitType, set := range other.Types {
+		result.Types[commitType] = result.Types[commitType].Replace(set)
+	}
+	return result
+}
+
+// validate reports settings that are invalid after the presets are applied.
+func (c *Config) validate() error {
+	if c.Format != "" {
+		if _, err := parser.ParseHeaderFormat(c.Format); err != nil {
+			return err
+		}
+	}
+	for commitType := range c.Types {
+		if err := c.validateTypes([]string{commitType}); err != nil {
+			return fmt.Errorf("types: %w", err)
+		}
+	}
+	for i, conditional := range c.Conditional {
+		if err := c.validateTypes(conditional.When.Type); err != nil {
+			return fmt.Errorf("conditional entry %d: %w", i+1, err)
+		}
+		if err := conditional.When.validate(); err != nil {
+			return fmt.Errorf("conditional entry %d: %w", i+1, err)
+		}
+	}
+	for i, profile := range c.Branches {
+		if len(profile.Branch) == 0 {
+			return fmt.Errorf("branches entry %d: branch is required", i+1)
+		}
+		if err := (Condition{Branch: profile.Branch}).validate(); err != nil {
+			return fmt.Errorf("branches entry %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateTypes(commitTypes []string) error {
+	allowed := c.CommitTypes
+	if allowed == nil {
+		allowed = rules.ConventionalCommitTypes
+	}
+	for _, commitType := range commitTypes {
+		if !slices.Contains(allowed, commitType) {
+			return fmt.Errorf("unknown commit type %q", commitType)
+		}
+	}
+	return nil
+}
+
+// HeaderFormat returns the configured header format.
+func (c *Config) HeaderFormat() parser.HeaderFormat {
+	// The format is checked when the configuration is parsed.
+	format, _ := parser.ParseHeaderFormat(c.Format)
+	return format
+}
+
+// AllowsGitmojiPrefix reports whether conventional headers may start with a
+// gitmoji.
+func (c *Config) AllowsGitmojiPrefix() bool {
+	return c.GitmojiPrefix != nil && *c.GitmojiPrefix
+}
+
+// TypeRules returns the rules added for commits of commitType, ignoring case
+// as the parser does when it accepts the type.
+func (c *Config) TypeRules(commitType string) RuleSet {
+	if set, ok := c.Types[commitType]; ok {
+		return set
+	}
+	for key, set := range c.Types {
+		if strings.EqualFold(key, commitType) {
+			return set
+		}
+	}
+	return RuleSet{}
+}
+
+// Profile returns the first branch profile matching branch, or nil.
+func (c *Config) Profile(branch string) *BranchProfile {
+	for i := range c.Branches {
+		if c.Branches[i].Matches(branch) {
+			return &c.Branches[i]
+		}
+	}
+	return nil
+}
+
+// RulesFor returns the rules for msg. The rules of the branch profile matching
+// msg.Branch replace those of base, and the rules of the profile, of the
+// commit type and of each matching conditional entry are added. stagedPaths
+// returns the staged files and is only called when a condition has path
+// patterns.
+func (c *Config) RulesFor(base RuleSet, msg *parser.CommitMessage, stagedPaths func() []string) RuleSet {
+	profile := c.Profile(msg.Branch)
+	set := base
+	if profile != nil {
+		set = set.Replace(profile.Rules).Merge(profile.RuleSet)
+	}
+	set = set.Merge(c.TypeRules(msg.Type))
+	for _, conditional := range c.Conditional {
+		if conditional.When.Matches(msg, stagedPaths) {
+			set = set.Merge(conditional.RuleSet)
+		}
+	}
+	return set
+}
+
+// SplitRules splits a comma-separated rule list. Commas inside parentheses
+// separate the arguments of a parameterized rule, e.g. "allowScripts(Latin, Greek)".
+func SplitRules(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	var ruleList []string
+	depth, start := 0, 0
+	for i, char := range value + "," {
+		switch {
+		case char == '(':
+			depth++
+		case char == ')' && depth > 0:
+			depth--
+		case char == ',' && depth == 0:
+			if ruleName := strings.TrimSpace(value[start:i]); ruleName != "" {
+				ruleList = append(ruleList, ruleName)
+			}
+			start = i + 1
+		}
+	}
+	return ruleList
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSplitRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{"empty", "", nil},
+		{"blank", "  ", nil},
+		{"single rule", "noCyrillic", []string{"noCyrillic"}},
+		{"several rules", "noCyrillic, capitalized,,oneLine", []string{"noCyrillic", "capitalized", "oneLine"}},
+		{"parameterized rule", "allowScripts(Latin, Greek),capitalized", []string{"allowScripts(Latin, Greek)", "capitalized"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitRules(tt.value); !slices.Equal(got, tt.want) {
+				t.Errorf("SplitRules(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	data := `
+rules:
+  description: [noCyrillic, allowScripts(Latin, Greek)]
+  body: []
+types:
+  feat, fix, perf:
+    body:
+      - notEmpty
+      - minWords(5)
+  fix:
+    message: signedOff
+  revert:
+    body: [revertsCommit]
+  chore:
+    body: [empty]
+`
+	cfg, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if want := (RuleList{"noCyrillic", "allowScripts(Latin, Greek)"}); !reflect.DeepEqual(cfg.Rules.Description, want) {
+		t.Errorf("Rules.Description = %q, want %q", cfg.Rules.Description, want)
+	}
+	if cfg.Rules.Body == nil || len(cfg.Rules.Body) != 0 {
+		t.Errorf("Rules.Body = %#v, want an empty non-nil list", cfg.Rules.Body)
+	}
+	if cfg.Rules.Type != nil {
+		t.Errorf("Rules.Type = %#v, want nil", cfg.Rules.Type)
+	}
+
+	tests := []struct {
+		commitType string
+		want       RuleSet
+	}{
+		{"feat", RuleSet{Body: RuleList{"notEmpty", "minWords(5)"}}},
+		{"perf", RuleSet{Body: RuleList{"notEmpty", "minWords(5)"}}},
+		{"fix", RuleSet{Body: RuleList{"notEmpty", "minWords(5)"}, Message: RuleList{"signedOff"}}},
+		{"revert", RuleSet{Body: RuleList{"revertsCommit"}}},
+		{"chore", RuleSet{Body: RuleList{"empty"}}},
+		{"docs", RuleSet{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.commitType, func(t *testing.T) {
+			got := cfg.TypeRules(tt.commitType)
+			if !slices.Equal(got.Body, tt.want.Body) || !slices.Equal(got.Message, tt.want.Message) || len(got.Type)+len(got.Scope)+len(got.Description) != 0 {
+				t.Errorf("TypeRules(%q) = %q, want %q", tt.commitType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOverlappingTypeKeys(t *testing.T) {
+	data := `
+types:
+  fix, perf:
+    body: [notEmpty]
+  fix:
+    message: [signedOff]
+    body: [minWords(5)]
+`
+	cfg, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got, want := cfg.TypeRules("fix"), (RuleSet{Body: RuleList{"minWords(5)", "notEmpty"}, Message: RuleList{"signedOff"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("TypeRules(fix) = %q, want %q", got, want)
+	}
+	// The rules of the separate fix key must not leak into perf.
+	if got, want := cfg.TypeRules("perf"), (RuleSet{Body: RuleList{"notEmpty"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("TypeRules(perf) = %q, want %q", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"unknown key", "rulez:\n  body: [notEmpty]\n"},
+		{"unknown part", "rules:\n  footer: [notEmpty]\n"},
+		{"unknown commit type", "types:\n  feature:\n    body: [notEmpty]\n"},
+		{"rules as a map", "rules:\n  body:\n    notEmpty: true\n"},
+		{"invalid yaml", "rules: [\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Errorf("Parse(%q) error = nil, want error", tt.data)
+			}
+		})
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	cfg, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := cfg.TypeRules("feat"); !reflect.DeepEqual(got, RuleSet{}) {
+		t.Errorf("TypeRules() = %q, want no rules", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("types:\n  chore:\n    body: [empty]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.TypeRules("chore").Body; !slices.Equal(got, RuleList{"empty"}) {
+		t.Errorf("TypeRules(chore).Body = %q, want [empty]", got)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load() of a missing file error = nil, want error")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := RuleSet{Description: RuleList{"noCyrillic"}}
+	got := base.Merge(RuleSet{Description: RuleList{"minWords(3)"}, Body: RuleList{"notEmpty"}})
+	if !slices.Equal(got.Description, []string{"noCyrillic", "minWords(3)"}) {
+		t.Errorf("Merge().Description = %q", got.Description)
+	}
+	if !slices.Equal(got.Body, []string{"notEmpty"}) {
+		t.Errorf("Merge().Body = %q", got.Body)
+	}
+	if len(base.Body) != 0 {
+		t.Errorf("Merge() modified the receiver")
+	}
+}
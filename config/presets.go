@@ -0,0 +1,22 @@
+package config
+
+import (
+	"embed"
+	"io/fs"
+	"strings"
+)
+
+// presetFiles holds the built-in presets, one configuration file each.
+//
+//go:embed presets/*.yaml
+var presetFiles embed.FS
+
+// Presets returns the names of the built-in presets.
+func Presets() []string {
+	entries, _ := fs.ReadDir(presetFiles, "presets")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	return names
+}
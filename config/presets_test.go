@@ -0,0 +1,168 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/parser"
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+)
+
+func TestPresets(t *testing.T) {
+	want := []string{"angular", "conventional", "gitmoji", "kernel"}
+	if got := Presets(); !slices.Equal(got, want) {
+		t.Fatalf("Presets() = %q, want %q", got, want)
+	}
+
+	for _, name := range want {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := Parse(nil, name)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			sets := []RuleSet{cfg.Rules}
+			for _, set := range cfg.Types {
+				sets = append(sets, set)
+			}
+			for _, set := range sets {
+				for _, rule := range slices.Concat(set.Type, set.Scope, set.Description, set.Body, set.Message) {
+					if _, err := rules.RuleFactory(rule); err != nil {
+						t.Errorf("preset rule %q: %v", rule, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPresetSettings(t *testing.T) {
+	angular, err := Parse(nil, "angular")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if slices.Contains(angular.CommitTypes, "chore") || !slices.Contains(angular.CommitTypes, "feat") {
+		t.Errorf("angular CommitTypes = %q", angular.CommitTypes)
+	}
+	// Settings of the extended conventional preset are kept.
+	if !slices.Equal(angular.Rules.Message, RuleList{"blankLineAfterHeader", "noMisplacedTrailers"}) {
+		t.Errorf("angular Rules.Message = %q", angular.Rules.Message)
+	}
+	if !slices.Equal(angular.TypeRules("feat").Body, RuleList{"notEmpty"}) || len(angular.TypeRules("docs").Body) != 0 {
+		t.Errorf("angular body rules: feat %q, docs %q", angular.TypeRules("feat").Body, angular.TypeRules("docs").Body)
+	}
+
+	kernel, err := Parse(nil, "kernel")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if kernel.HeaderFormat() != parser.Kernel {
+		t.Errorf("kernel HeaderFormat() = %v, want Kernel", kernel.HeaderFormat())
+	}
+	if !slices.Contains(kernel.Rules.Message, "signedOff") {
+		t.Errorf("kernel Rules.Message = %q, want signedOff", kernel.Rules.Message)
+	}
+
+	if got := (&Config{}).HeaderFormat(); got != parser.Conventional {
+		t.Errorf("default HeaderFormat() = %v, want Conventional", got)
+	}
+	if (&Config{}).AllowsGitmojiPrefix() || kernel.AllowsGitmojiPrefix() {
+		t.Error("AllowsGitmojiPrefix() = true, want conventional headers without a gitmoji by default")
+	}
+
+	// Extending gitmoji with conventional headers accepts "✨ feat: add login".
+	gitmoji, err := Parse([]byte("extends: gitmoji\nformat: conventional\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if gitmoji.HeaderFormat() != parser.Conventional || !gitmoji.AllowsGitmojiPrefix() {
+		t.Errorf("gitmoji extension: HeaderFormat() = %v, AllowsGitmojiPrefix() = %v", gitmoji.HeaderFormat(), gitmoji.AllowsGitmojiPrefix())
+	}
+	disabled, err := Parse([]byte("extends: gitmoji\ngitmojiPrefix: false\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if disabled.AllowsGitmojiPrefix() {
+		t.Error("AllowsGitmojiPrefix() = true, want gitmojiPrefix: false to override the preset")
+	}
+}
+
+func TestParseExtends(t *testing.T) {
+	data := `
+extends: angular
+commitTypes: [build, ci, docs, feat, fix, perf, refactor, test, chore]
+rules:
+  description: [startLowerCase]
+types:
+  feat:
+    body: []
+  chore:
+    body: [empty]
+`
+	cfg, err := Parse([]byte(data), "kernel")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// Presets given to Parse come first, so the extended angular preset
+	// overrides the kernel format.
+	if cfg.HeaderFormat() != parser.Conventional {
+		t.Errorf("HeaderFormat() = %v, want Conventional", cfg.HeaderFormat())
+	}
+	if !slices.Equal(cfg.Rules.Description, RuleList{"startLowerCase"}) {
+		t.Errorf("Rules.Description = %q, want [startLowerCase]", cfg.Rules.Description)
+	}
+	// Parts the file does not set keep the rules of the last preset that
+	// sets them: conventional, extended by angular, replaces kernel's.
+	if !slices.Equal(cfg.Rules.Message, RuleList{"blankLineAfterHeader", "noMisplacedTrailers"}) {
+		t.Errorf("Rules.Message = %q, want the conventional preset rules", cfg.Rules.Message)
+	}
+	if !slices.Equal(cfg.Rules.Scope, RuleList{"allowScope", "kebabCase"}) {
+		t.Errorf("Rules.Scope = %q, want the angular preset rules", cfg.Rules.Scope)
+	}
+	if got := cfg.TypeRules("feat").Body; got == nil || len(got) != 0 {
+		t.Errorf("TypeRules(feat).Body = %#v, want an empty list", got)
+	}
+	if !slices.Equal(cfg.TypeRules("fix").Body, RuleList{"notEmpty"}) {
+		t.Errorf("TypeRules(fix).Body = %q, want [notEmpty]", cfg.TypeRules("fix").Body)
+	}
+	if !slices.Equal(cfg.TypeRules("chore").Body, RuleList{"empty"}) {
+		t.Errorf("TypeRules(chore).Body = %q, want [empty]", cfg.TypeRules("chore").Body)
+	}
+}
+
+func TestOverrideBranches(t *testing.T) {
+	base := &Config{Branches: []BranchProfile{{Branch: StringList{"*"}, RuleSet: RuleSet{Message: RuleList{"signedOff"}}}}}
+	other := &Config{Branches: []BranchProfile{{Branch: StringList{"main"}, RuleSet: RuleSet{Message: RuleList{"noFixup"}}}}}
+	cfg := base.Override(other)
+	if got := cfg.Profile("main").Message; !slices.Equal(got, RuleList{"noFixup"}) {
+		t.Errorf("Profile(main).Message = %q, want the overriding profile", got)
+	}
+	if got := cfg.Profile("develop").Message; !slices.Equal(got, RuleList{"signedOff"}) {
+		t.Errorf("Profile(develop).Message = %q, want the base profile", got)
+	}
+}
+
+func TestParsePresetErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		presets []string
+	}{
+		{"unknown preset", "", []string{"strict"}},
+		{"unknown extended preset", "extends: [conventional, strict]\n", nil},
+		{"unknown format", "format: angular\n", nil},
+		{"type outside preset types", "extends: angular\ntypes:\n  chore:\n    body: [empty]\n", nil},
+		{"conditional type outside commit types", "commitTypes: [feature]\nconditional:\n  - when:\n      type: feat\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data), tt.presets...); err == nil {
+				t.Errorf("Parse(%q, %q) error = nil, want error", tt.data, tt.presets)
+			}
+		})
+	}
+
+	if _, err := resolve([]byte("extends: kernel\n"), nil, []string{"kernel"}); err == nil {
+		t.Error("resolve() of a preset extending itself error = nil, want error")
+	}
+}
@@ -0,0 +1,140 @@
+// Package git reads repository state by running the git command.
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// run runs git with args in the current directory and returns its output
+// without the trailing newline.
+func run(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// Config returns the value of a git configuration key, or "" when the key is
+// not set.
+func Config(key string) (string, error) {
+	value, err := run("config", "--get", key)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	return value, err
+}
+
+// CurrentBranch returns the short name of the checked out branch. During a
+// rebase HEAD is detached, so the branch being rebased is returned instead;
+// a cherry-pick or revert in progress keeps HEAD on its branch. It returns ""
+// on a detached HEAD otherwise.
+func CurrentBranch() (string, error) {
+	if branch, err := run("symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		return branch, nil
+	}
+	for _, state := range []string{"rebase-merge/head-name", "rebase-apply/head-name"} {
+		path, err := run("rev-parse", "--git-path", state)
+		if err != nil {
+			return "", err
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			// Rebasing a detached HEAD records "detached HEAD".
+			name := strings.TrimSpace(string(data))
+			if !strings.HasPrefix(name, "refs/heads/") {
+				return "", nil
+			}
+			return strings.TrimPrefix(name, "refs/heads/"), nil
+		}
+	}
+	// Fail outside a repository, but not on a detached HEAD.
+	if _, err := run("rev-parse", "--git-dir"); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// AuthorIdent returns the author of the commit being made as "Name <email>",
+// from GIT_AUTHOR_NAME and GIT_AUTHOR_EMAIL when both are set, as they are
+// while Git runs hooks, or else from "git var GIT_AUTHOR_IDENT".
+func AuthorIdent() (string, error) {
+	name, email := os.Getenv("GIT_AUTHOR_NAME"), os.Getenv("GIT_AUTHOR_EMAIL")
+	if name != "" && email != "" {
+		return name + " <" + email + ">", nil
+	}
+	ident, err := run("var", "GIT_AUTHOR_IDENT")
+	if err != nil {
+		return "", err
+	}
+	// The identity is followed by a timestamp and time zone.
+	if end := strings.LastIndexByte(ident, '>'); end >= 0 {
+		ident = ident[:end+1]
+	}
+	return ident, nil
+}
+
+// StagedPaths returns the paths, relative to the repository root, of the
+// files added, changed, renamed or deleted in the index.
+func StagedPaths() ([]string, error) {
+	output, err := run("diff", "--cached", "--name-only", "--no-renames", "-z")
+	if err != nil {
+		return nil, err
+	}
+	return splitPaths(output), nil
+}
+
+// Commit is a commit read from the repository history.
+type Commit struct {
+	Hash string
+	// Author is the commit author as "Name <email>".
+	Author  string
+	Message string
+}
+
+// Commits returns the commits in a revision range such as "origin/main..HEAD",
+// oldest first. Merge commits are skipped, since their messages are written
+// by Git.
+func Commits(revisionRange string) ([]Commit, error) {
+	output, err := run("log", "-z", "--no-merges", "--reverse", "--format=%H%n%an <%ae>%n%B", "--end-of-options", revisionRange, "--")
+	if err != nil {
+		return nil, err
+	}
+	var commits []Commit
+	for _, entry := range strings.Split(output, "\x00") {
+		fields := strings.SplitN(entry, "\n", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		commits = append(commits, Commit{Hash: fields[0], Author: fields[1], Message: fields[2]})
+	}
+	return commits, nil
+}
+
+// CommitPaths returns the paths, relative to the repository root, of the
+// files added, changed, renamed or deleted by a commit.
+func CommitPaths(hash string) ([]string, error) {
+	output, err := run("diff-tree", "--no-commit-id", "--name-only", "--no-renames", "-r", "-z", "--root", "--end-of-options", hash)
+	if err != nil {
+		return nil, err
+	}
+	return splitPaths(output), nil
+}
+
+// splitPaths splits the NUL-separated output of a git command run with -z.
+func splitPaths(output string) []string {
+	var paths []string
+	for _, path := range strings.Split(output, "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,237 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfig(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "i18n.commitEncoding")
+	t.Setenv("GIT_CONFIG_VALUE_0", "windows-1251")
+
+	got, err := Config("i18n.commitEncoding")
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+	if got != "windows-1251" {
+		t.Errorf("Config() = %q, want %q", got, "windows-1251")
+	}
+
+	got, err = Config("commit-msg-guardian.unset")
+	if err != nil || got != "" {
+		t.Errorf("Config() of an unset key = %q, %v, want empty", got, err)
+	}
+}
+
+// newRepository creates a repository with one commit on branch and makes it
+// the current directory for the rest of the test.
+func newRepository(t *testing.T, branch string) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	gitCommand(t, "init", "--quiet")
+	gitCommand(t, "checkout", "--quiet", "-b", branch)
+	gitCommand(t, "commit", "--quiet", "--allow-empty", "-m", "chore: initial commit")
+	return dir
+}
+
+func gitCommand(t *testing.T, args ...string) {
+	t.Helper()
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+func TestCurrentBranch(t *testing.T) {
+	dir := newRepository(t, "feature/TGK-1827-login")
+
+	if got, err := CurrentBranch(); err != nil || got != "feature/TGK-1827-login" {
+		t.Errorf("CurrentBranch() = %q, %v, want feature/TGK-1827-login", got, err)
+	}
+
+	gitCommand(t, "checkout", "--quiet", "--detach")
+	if got, err := CurrentBranch(); err != nil || got != "" {
+		t.Errorf("CurrentBranch() on a detached HEAD = %q, %v, want empty", got, err)
+	}
+
+	// An interactive rebase detaches HEAD and records the rebased branch.
+	rebaseDir := filepath.Join(dir, ".git", "rebase-merge")
+	if err := os.MkdirAll(rebaseDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rebaseDir, "head-name"), []byte("refs/heads/feature/TGK-1827-login\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := CurrentBranch(); err != nil || got != "feature/TGK-1827-login" {
+		t.Errorf("CurrentBranch() during a rebase = %q, %v, want feature/TGK-1827-login", got, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(rebaseDir, "head-name"), []byte("detached HEAD\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := CurrentBranch(); err != nil || got != "" {
+		t.Errorf("CurrentBranch() during a rebase of a detached HEAD = %q, %v, want empty", got, err)
+	}
+}
+
+func TestCurrentBranchDuringCherryPick(t *testing.T) {
+	dir := newRepository(t, "main")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("base\n")
+	gitCommand(t, "add", "file.txt")
+	gitCommand(t, "commit", "--quiet", "-m", "feat: add file")
+	gitCommand(t, "checkout", "--quiet", "-b", "release/1.2")
+	write("release\n")
+	gitCommand(t, "commit", "--quiet", "-am", "fix: change file on release")
+	gitCommand(t, "checkout", "--quiet", "main")
+	write("main\n")
+	gitCommand(t, "commit", "--quiet", "-am", "fix: change file on main")
+
+	// The conflicting cherry-pick stops and leaves the commit to the user.
+	if err := exec.Command("git", "cherry-pick", "release/1.2").Run(); err == nil {
+		t.Fatal("git cherry-pick succeeded, want a conflict")
+	}
+	if got, err := CurrentBranch(); err != nil || got != "main" {
+		t.Errorf("CurrentBranch() during a cherry-pick = %q, %v, want main", got, err)
+	}
+}
+
+func TestCurrentBranchOutsideRepository(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+
+	if _, err := CurrentBranch(); err == nil {
+		t.Error("CurrentBranch() expected error outside a repository")
+	}
+}
+
+func TestAuthorIdent(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Jane Doe")
+	t.Setenv("GIT_AUTHOR_EMAIL", "jane@example.com")
+	if got, err := AuthorIdent(); err != nil || got != "Jane Doe <jane@example.com>" {
+		t.Errorf("AuthorIdent() = %q, %v, want Jane Doe <jane@example.com>", got, err)
+	}
+
+	newRepository(t, "main")
+	gitCommand(t, "config", "user.name", "John Roe")
+	gitCommand(t, "config", "user.email", "john@example.com")
+	t.Setenv("GIT_AUTHOR_NAME", "")
+	t.Setenv("GIT_AUTHOR_EMAIL", "")
+	os.Unsetenv("GIT_AUTHOR_NAME")
+	os.Unsetenv("GIT_AUTHOR_EMAIL")
+	if got, err := AuthorIdent(); err != nil || got != "John Roe <john@example.com>" {
+		t.Errorf("AuthorIdent() from git var = %q, %v, want John Roe <john@example.com>", got, err)
+	}
+}
+
+func TestStagedPaths(t *testing.T) {
+	dir := newRepository(t, "main")
+
+	if got, err := StagedPaths(); err != nil || len(got) != 0 {
+		t.Errorf("StagedPaths() without changes = %q, %v, want none", got, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "db", "migrations"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"README.md", "db/migrations/001 init.sql", "unstaged.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitCommand(t, "add", "README.md", "db/migrations")
+
+	got, err := StagedPaths()
+	if err != nil {
+		t.Fatalf("StagedPaths() error = %v", err)
+	}
+	want := []string{"README.md", "db/migrations/001 init.sql"}
+	if !slices.Equal(got, want) {
+		t.Errorf("StagedPaths() = %q, want %q", got, want)
+	}
+}
+
+func TestCommits(t *testing.T) {
+	newRepository(t, "main")
+	gitCommand(t, "checkout", "--quiet", "-b", "feature")
+	gitCommand(t, "commit", "--quiet", "--allow-empty", "-m", "feat: add login\n\nUsers can sign in.")
+	gitCommand(t, "checkout", "--quiet", "-b", "side", "main")
+	gitCommand(t, "commit", "--quiet", "--allow-empty", "-m", "fix: side fix")
+	gitCommand(t, "checkout", "--quiet", "feature")
+	gitCommand(t, "merge", "--quiet", "--no-edit", "side")
+	gitCommand(t, "commit", "--quiet", "--allow-empty", "-m", "docs: describe login")
+
+	commits, err := Commits("main..feature")
+	if err != nil {
+		t.Fatalf("Commits() error = %v", err)
+	}
+	var messages []string
+	for _, commit := range commits {
+		if len(commit.Hash) != 40 {
+			t.Errorf("Commits() hash = %q, want a full hash", commit.Hash)
+		}
+		if commit.Author != "Test <test@example.com>" {
+			t.Errorf("Commits() author = %q, want Test <test@example.com>", commit.Author)
+		}
+		messages = append(messages, commit.Message)
+	}
+	// Commits of parallel branches made within the same second have no
+	// defined order.
+	slices.Sort(messages)
+	want := []string{"docs: describe login\n", "feat: add login\n\nUsers can sign in.\n", "fix: side fix\n"}
+	if !slices.Equal(messages, want) {
+		t.Errorf("Commits() messages = %q, want %q", messages, want)
+	}
+
+	if commits, err := Commits("feature..feature"); err != nil || len(commits) != 0 {
+		t.Errorf("Commits() of an empty range = %v, %v, want none", commits, err)
+	}
+	if _, err := Commits("main..missing"); err == nil {
+		t.Error("Commits() of an unknown revision error = nil, want error")
+	}
+}
+
+func TestCommitPaths(t *testing.T) {
+	dir := newRepository(t, "main")
+	if err := os.WriteFile(filepath.Join(dir, "schema.sql"), []byte("x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitCommand(t, "add", "schema.sql")
+	gitCommand(t, "commit", "--quiet", "-m", "feat: add schema")
+
+	got, err := CommitPaths("HEAD")
+	if err != nil || !slices.Equal(got, []string{"schema.sql"}) {
+		t.Errorf("CommitPaths(HEAD) = %q, %v, want [schema.sql]", got, err)
+	}
+	// The root commit is compared with an empty tree.
+	if got, err := CommitPaths("HEAD~1"); err != nil || len(got) != 0 {
+		t.Errorf("CommitPaths(HEAD~1) = %q, %v, want none", got, err)
+	}
+}
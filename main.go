@@ -1,25 +1,58 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"strings"
 
+	"github.com/AnruKitakaze/commit-msg-guardian/config"
+	"github.com/AnruKitakaze/commit-msg-guardian/git"
 	"github.com/AnruKitakaze/commit-msg-guardian/parser"
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
 )
 
 func main() {
 	// Define flags for rules
-	typeRules := flag.String("type-rules", "allowLatin", "Comma-separated rules for commit type")
+	typeRules := flag.String("type-rules", "allowLatin,lowerCase", "Comma-separated rules for commit type")
 	scopeRules := flag.String("scope-rules", "allowScope", "Comma-separated rules for commit scope")
 	descriptionRules := flag.String("description-rules", "noCyrillic", "Comma-separated rules for commit description")
 	bodyRules := flag.String("body-rules", "", "Comma-separated rules for commit body")
+	messageRules := flag.String("message-rules", "", "Comma-separated rules for the whole commit message")
+	headerLengthLimit := flag.Int("header-length-limit", 0, "Maximum allowed header (whole first line) length; 0 disables the limit")
 	descriptionLengthLimit := flag.Int("description-length-limit", 0, "Maximum allowed description length; 0 disables the limit")
 	bodyLengthLimit := flag.Int("body-length-limit", 0, "Maximum allowed body length; 0 disables the limit")
+	bodyLineLengthLimit := flag.Int("body-line-length-limit", 0, "Maximum allowed length of each body and footer line; 0 disables the limit")
+	descriptionMinLength := flag.Int("description-min-length", 0, "Minimum required description length; 0 disables the limit")
+	bodyMinLength := flag.Int("body-min-length", 0, "Minimum required body length; 0 disables the limit")
+	lengthUnit := flag.String("length-unit", "runes", "Unit for all length limits: bytes, runes, graphemes or columns")
+	normalization := flag.String("normalization", "NFC", "Unicode normalization applied before validation: NFC, NFD, NFKC, NFKD or none")
+	requireReference := flag.String("require-reference", "", "Require a ticket reference matching jira, github, gitlab or a regular expression")
+	referenceLocations := flag.String("reference-locations", "", "Comma-separated parts that may hold the required reference: scope, description, body, footer; defaults to all")
+	referenceTypes := flag.String("reference-types", "", "Comma-separated commit types that require a reference; defaults to all")
+	prepareCommitMsg := flag.Bool("prepare-commit-msg", false, "Run as a prepare-commit-msg hook: pre-fill the ticket from the branch name instead of validating")
+	branchTicketPattern := flag.String("branch-ticket-pattern", "jira", "Pattern of the ticket in branch names for --prepare-commit-msg: jira, github, gitlab or a regular expression")
+	encoding := flag.String("encoding", "", "Encoding of the commit message file; defaults to git's i18n.commitEncoding, then UTF-8")
+	preset := flag.String("preset", "", "Comma-separated presets the configuration builds on: "+strings.Join(config.Presets(), ", "))
+	configFile := flag.String("config", "", "Configuration file with default, per-type and conditional rules; defaults to "+config.FileName+" if it exists")
+	commitRange := flag.String("range", "", "Validate the messages of the commits in a revision range such as origin/main..HEAD instead of a message file")
+	targetBranch := flag.String("target-branch", "", "Branch to check commits against, e.g. the target branch of a pull request with --range; defaults to the current branch")
+	fix := flag.Bool("fix", false, "Apply automatic fixes of the configured rules to the commit message file before validating it")
 
 	flag.Parse()
 
+	cfg, err := loadConfig(*configFile, config.SplitRules(*preset))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *headerLengthLimit < 0 {
+		fmt.Fprintln(os.Stderr, "Error: header length limit must be non-negative")
+		os.Exit(1)
+	}
 	if *descriptionLengthLimit < 0 {
 		fmt.Fprintln(os.Stderr, "Error: description length limit must be non-negative")
 		os.Exit(1)
@@ -28,6 +61,96 @@ func main() {
 		fmt.Fprintln(os.Stderr, "Error: body length limit must be non-negative")
 		os.Exit(1)
 	}
+	if *bodyLineLengthLimit < 0 {
+		fmt.Fprintln(os.Stderr, "Error: body line length limit must be non-negative")
+		os.Exit(1)
+	}
+	if *descriptionMinLength < 0 {
+		fmt.Fprintln(os.Stderr, "Error: description min length must be non-negative")
+		os.Exit(1)
+	}
+	if *bodyMinLength < 0 {
+		fmt.Fprintln(os.Stderr, "Error: body min length must be non-negative")
+		os.Exit(1)
+	}
+	unit, err := rules.ParseLengthUnit(*lengthUnit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *requireReference != "" {
+		if _, err := rules.ParseReferencePattern(*requireReference); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	parseOptions := parser.ParseOptions{
+		SkipNormalization: strings.EqualFold(*normalization, "none"),
+		Format:            cfg.HeaderFormat(),
+		Types:             cfg.CommitTypes,
+		Gitmoji:           cfg.AllowsGitmojiPrefix(),
+	}
+	if !parseOptions.SkipNormalization {
+		parseOptions.Normalization, err = rules.ParseNormalForm(*normalization)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Split rules into slices; the configuration file replaces the defaults
+	// of the options that are not given
+	given := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	check := &checker{
+		config: cfg,
+		rules: config.RuleSet{
+			Type:        ruleOption(given["type-rules"], *typeRules, cfg.Rules.Type),
+			Scope:       ruleOption(given["scope-rules"], *scopeRules, cfg.Rules.Scope),
+			Description: ruleOption(given["description-rules"], *descriptionRules, cfg.Rules.Description),
+			Body:        ruleOption(given["body-rules"], *bodyRules, cfg.Rules.Body),
+			Message:     ruleOption(given["message-rules"], *messageRules, cfg.Rules.Message),
+		},
+		limits: parser.LengthLimits{
+			Header:         *headerLengthLimit,
+			Description:    *descriptionLengthLimit,
+			Body:           *bodyLengthLimit,
+			BodyLine:       *bodyLineLengthLimit,
+			DescriptionMin: *descriptionMinLength,
+			BodyMin:        *bodyMinLength,
+			Unit:           unit,
+		},
+		references: parser.ReferenceRequirement{
+			Pattern:   *requireReference,
+			Locations: config.SplitRules(*referenceLocations),
+			Types:     config.SplitRules(*referenceTypes),
+		},
+		options: parseOptions,
+	}
+
+	// Rules such as branchTicket check the message against the branch, which
+	// is unknown outside a repository and on a detached HEAD.
+	branch := *targetBranch
+	if branch == "" {
+		branch, _ = git.CurrentBranch()
+	}
+
+	// Validate the commits of a range instead of a message file
+	if *commitRange != "" {
+		if *fix || *prepareCommitMsg {
+			fmt.Fprintln(os.Stderr, "Error: --range cannot be combined with --fix or --prepare-commit-msg")
+			os.Exit(1)
+		}
+		failed, err := checkRange(*commitRange, branch, check)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading commits: %v\n", err)
+			os.Exit(1)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
 	// Get commit message file path from arguments
 	if len(flag.Args()) < 1 {
@@ -35,33 +158,66 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Read commit message file
+	// Pre-fill the ticket instead of validating
 	commitMsgFile := flag.Args()[0]
-	commitMsg, err := os.ReadFile(commitMsgFile)
+	if *prepareCommitMsg {
+		// Git passes the message source as the second argument; pre-commit
+		// passes it in the environment.
+		source := os.Getenv("PRE_COMMIT_COMMIT_MSG_SOURCE")
+		if len(flag.Args()) > 1 {
+			source = flag.Args()[1]
+		}
+		if err := prepareCommitMessage(commitMsgFile, source, *encoding, branch, *branchTicketPattern, parseOptions); err != nil {
+			fmt.Fprintf(os.Stderr, "Error preparing commit message: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Read commit message file
+	commitMsgBytes, err := os.ReadFile(commitMsgFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading commit message file: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Decode commit message from the configured encoding
+	if *encoding == "" {
+		// Outside a repository or without git there is no configured encoding.
+		*encoding, _ = git.Config("i18n.commitEncoding")
+	}
+	commitMsg, err := parser.DecodeMessage(commitMsgBytes, *encoding)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading commit message file: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Parse commit message
-	msg, err := parser.ParseCommitMessage(string(commitMsg))
+	msg, err := parser.ParseCommitMessageWithOptions(commitMsg, parseOptions)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing commit message: %v\n", err)
 		os.Exit(1)
 	}
+	msg.Branch = branch
+	msg.Author, _ = git.AuthorIdent()
 
-	// Split rules into slices
-	typeRulesList := splitRules(*typeRules)
-	scopeRulesList := splitRules(*scopeRules)
-	descriptionRulesList := splitRules(*descriptionRules)
-	bodyRulesList := splitRules(*bodyRules)
+	// Apply the branch profile and add the rules for the commit type and the
+	// matching conditions
+	ruleSet := check.rulesFor(msg, git.StagedPaths)
 
-	// Validate commit message
-	if err := msg.ValidateWithRules(typeRulesList, scopeRulesList, descriptionRulesList, bodyRulesList); err != nil {
-		fmt.Fprintf(os.Stderr, "Commit message validation failed: %v\n", err)
-		os.Exit(1)
+	// Apply automatic fixes
+	if *fix {
+		msg, err = fixCommitMessage(commitMsgFile, *encoding, msg, parseOptions, ruleSet)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fixing commit message: %v\n", err)
+			os.Exit(1)
+		}
+		// A fixed type, such as "Feat" fixed to "feat", may select other rules.
+		ruleSet = check.rulesFor(msg, git.StagedPaths)
 	}
-	if err := msg.ValidateLengthLimits(*descriptionLengthLimit, *bodyLengthLimit); err != nil {
+
+	// Validate commit message
+	if err := check.validate(msg, ruleSet); err != nil {
 		fmt.Fprintf(os.Stderr, "Commit message validation failed: %v\n", err)
 		os.Exit(1)
 	}
@@ -69,19 +225,90 @@ func main() {
 	os.Exit(0)
 }
 
-func splitRules(value string) []string {
-	if strings.TrimSpace(value) == "" {
+// fixCommitMessage rewrites the commit message file with the rules' automatic
+// fixes applied, in the file's encoding, and returns the re-parsed message. The
+// file is left untouched when the fixed message is the message read from it,
+// without its comment lines.
+func fixCommitMessage(path, encoding string, msg *parser.CommitMessage, options parser.ParseOptions, ruleSet config.RuleSet) (*parser.CommitMessage, error) {
+	fixed, err := msg.FixWithRules(ruleSet.Type, ruleSet.Scope, ruleSet.Description, ruleSet.Body, ruleSet.Message)
+	if err != nil {
+		return nil, err
+	}
+	if fixed == msg.Raw {
+		return msg, nil
+	}
+	data, err := parser.EncodeMessage(fixed, encoding)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, err
+	}
+	fmt.Fprintln(os.Stderr, "Applied automatic fixes to the commit message")
+	fixedMsg, err := parser.ParseCommitMessageWithOptions(fixed, options)
+	if err != nil {
+		return nil, err
+	}
+	fixedMsg.Branch = msg.Branch
+	fixedMsg.Author = msg.Author
+	return fixedMsg, nil
+}
+
+// prepareCommitMessage adds the ticket found in the branch name to
+// the commit message file. Merges, squashes and amended commits already have
+// their message and are left alone, as are branches without a ticket.
+func prepareCommitMessage(path, source, encoding, branch, ticketPattern string, options parser.ParseOptions) error {
+	if source == "merge" || source == "squash" || source == "commit" {
 		return nil
 	}
+	pattern, err := rules.ParseReferencePattern(ticketPattern)
+	if err != nil {
+		return err
+	}
+	ticket := rules.BranchTicket(branch, pattern)
+	if ticket == "" {
+		return nil
+	}
+
+	if encoding == "" {
+		encoding, _ = git.Config("i18n.commitEncoding")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	message, err := parser.DecodeMessage(data, encoding)
+	if err != nil {
+		return err
+	}
+	prepared := parser.PrefillTicket(message, ticket, options)
+	if prepared == message {
+		return nil
+	}
+	data, err = parser.EncodeMessage(prepared, encoding)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
 
-	ruleNames := strings.Split(value, ",")
-	rules := make([]string, 0, len(ruleNames))
-	for _, ruleName := range ruleNames {
-		ruleName = strings.TrimSpace(ruleName)
-		if ruleName == "" {
-			continue
+// loadConfig reads the configuration file at path, or config.FileName when
+// path is empty, over presets. Only a missing default file is not an error.
+func loadConfig(path string, presets []string) (*config.Config, error) {
+	if path == "" {
+		path = config.FileName
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return config.Parse(nil, presets...)
 		}
-		rules = append(rules, ruleName)
 	}
-	return rules
+	return config.Load(path, presets...)
+}
+
+// ruleOption returns the rules of a rule option: the option value when it is
+// given on the command line, else the configured rules, else the default.
+func ruleOption(given bool, value string, configured config.RuleList) []string {
+	if !given && configured != nil {
+		return configured
+	}
+	return config.SplitRules(value)
 }
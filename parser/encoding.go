@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// lookupEncoding returns the encoding named by git's i18n.commitEncoding, such
+// as "windows-1251" or "KOI8-R", or nil for UTF-8 and an empty name.
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "utf-8") || strings.EqualFold(name, "utf8") {
+		return nil, nil
+	}
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported commit encoding: %s", name)
+	}
+	return enc, nil
+}
+
+// DecodeMessage converts a commit message file in the named encoding to UTF-8.
+// Messages in UTF-8, or with no encoding named, are returned unchanged, even
+// if they are not valid UTF-8.
+func DecodeMessage(data []byte, encodingName string) (string, error) {
+	enc, err := lookupEncoding(encodingName)
+	if err != nil {
+		return "", err
+	}
+	if enc == nil {
+		return string(data), nil
+	}
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", fmt.Errorf("commit message is not valid %s: %v", encodingName, err)
+	}
+	return string(decoded), nil
+}
+
+// EncodeMessage converts a UTF-8 commit message back to the named encoding.
+func EncodeMessage(message string, encodingName string) ([]byte, error) {
+	enc, err := lookupEncoding(encodingName)
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return []byte(message), nil
+	}
+	encoded, err := enc.NewEncoder().Bytes([]byte(message))
+	if err != nil {
+		return nil, fmt.Errorf("commit message cannot be encoded in %s: %v", encodingName, err)
+	}
+	return encoded, nil
+}
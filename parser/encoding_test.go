@@ -0,0 +1,46 @@
+package parser
+
+import "testing"
+
+func TestDecodeMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		encoding string
+		want     string
+		wantErr  bool
+	}{
+		{"no encoding", "feat: добавить x", "", "feat: добавить x", false},
+		{"utf-8", "feat: добавить x", "UTF-8", "feat: добавить x", false},
+		{"invalid utf-8 kept", "feat: \xe4\xee", "", "feat: \xe4\xee", false},
+		{"windows-1251", "feat: \xe4\xee\xe1\xe0\xe2\xe8\xf2\xfc x", "windows-1251", "feat: добавить x", false},
+		{"cp1251 alias", "feat: \xe4\xee\xe1\xe0\xe2\xe8\xf2\xfc x", "cp1251", "feat: добавить x", false},
+		{"koi8-r", "feat: \xc4\xcf\xc2\xc1\xd7\xc9\xd4\xd8 x", "KOI8-R", "feat: добавить x", false},
+		{"unknown encoding", "feat: add x", "no-such-encoding", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeMessage([]byte(tt.data), tt.encoding)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeMessage(t *testing.T) {
+	got, err := EncodeMessage("feat: добавить x", "windows-1251")
+	if err != nil {
+		t.Fatalf("EncodeMessage() error = %v", err)
+	}
+	if want := "feat: \xe4\xee\xe1\xe0\xe2\xe8\xf2\xfc x"; string(got) != want {
+		t.Errorf("EncodeMessage() = %q, want %q", got, want)
+	}
+	if _, err := EncodeMessage("feat: add ✨", "windows-1251"); err == nil {
+		t.Error("EncodeMessage() expected error for a character missing from windows-1251")
+	}
+}
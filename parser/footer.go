@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+)
+
+// Footer is a Conventional Commits footer (Git trailer) such as
+// "Refs: #123" or "BREAKING CHANGE: drop the v1 API".
+type Footer struct {
+	Token string
+	Value string
+}
+
+// parseFooters returns the footers found in the last paragraph of body.
+func parseFooters(body string) []Footer {
+	lines := strings.Split(body, "\n")
+	var footers []Footer
+	for _, line := range lines[rules.TrailerBlockStart(lines):] {
+		if token, _, value, ok := rules.ParseTrailerLine(line); ok {
+			footers = append(footers, Footer{Token: token, Value: value})
+			continue
+		}
+		if len(footers) > 0 && strings.TrimSpace(line) != "" {
+			last := &footers[len(footers)-1]
+			last.Value += "\n" + strings.TrimSpace(line)
+		}
+	}
+	return footers
+}
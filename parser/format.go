@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+)
+
+// HeaderFormat selects the syntax of the header line.
+type HeaderFormat int
+
+const (
+	// Conventional headers are "type(scope)!: description", optionally
+	// after a gitmoji with ParseOptions.Gitmoji, e.g. "✨ feat: add login".
+	Conventional HeaderFormat = iota
+	// Kernel headers are "subsystem: description" as in the Linux kernel,
+	// e.g. "net: ipv4: fix ...". The subsystem is parsed as the scope, and
+	// there is no type.
+	Kernel
+	// Gitmoji headers start with an emoji or its :shortcode:, e.g.
+	// "✨ Add login" or ":sparkles: Add login". There is no type.
+	Gitmoji
+)
+
+// ParseHeaderFormat returns the HeaderFormat with the given name:
+// conventional, kernel or gitmoji.
+func ParseHeaderFormat(name string) (HeaderFormat, error) {
+	switch strings.ToLower(name) {
+	case "conventional":
+		return Conventional, nil
+	case "kernel":
+		return Kernel, nil
+	case "gitmoji":
+		return Gitmoji, nil
+	default:
+		return Conventional, fmt.Errorf("unknown header format: %s", name)
+	}
+}
+
+var (
+	conventionalHeaderPattern = regexp.MustCompile(`^(\w+)(?:\(([\w-]+(?:/[\w-]+)*)\))?(!)?: (.+)$`)
+	// kernelHeaderPattern captures one or more "subsystem: " prefixes, such
+	// as "drm/i915: " or "net: ipv4: ", and the description.
+	kernelHeaderPattern  = regexp.MustCompile(`^((?:[\w./,+-]+: )+)(.+)$`)
+	gitmojiHeaderPattern = regexp.MustCompile(`^(\S+) (.+)$`)
+	gitmojiShortcode     = regexp.MustCompile(`^:[a-z0-9_+-]+:$`)
+)
+
+// parseHeader sets the header fields of cm from header, which follows the
+// autosquash prefix.
+func (cm *CommitMessage) parseHeader(header string, options ParseOptions) error {
+	switch options.Format {
+	case Kernel:
+		matches := kernelHeaderPattern.FindStringSubmatch(header)
+		if matches == nil {
+			return fmt.Errorf("invalid commit message format: expected \"subsystem: description\"")
+		}
+		cm.Scope = strings.TrimSuffix(matches[1], ": ")
+		cm.Description = matches[2]
+	case Gitmoji:
+		matches := gitmojiHeaderPattern.FindStringSubmatch(header)
+		if matches == nil || !isGitmoji(matches[1]) {
+			return fmt.Errorf("invalid commit message format: expected an emoji or :shortcode: followed by the description")
+		}
+		cm.Gitmoji = matches[1]
+		cm.Description = matches[2]
+	default:
+		if matches := gitmojiHeaderPattern.FindStringSubmatch(header); options.Gitmoji && matches != nil && isGitmoji(matches[1]) {
+			cm.Gitmoji = matches[1]
+			header = matches[2]
+		}
+		matches := conventionalHeaderPattern.FindStringSubmatch(header)
+		if matches == nil {
+			return fmt.Errorf("invalid commit message format")
+		}
+		if !isValidCommitType(matches[1], options.Types) {
+			return fmt.Errorf("invalid commit type: %s", matches[1])
+		}
+		cm.Type = matches[1]
+		cm.Scope = matches[2]
+		cm.BreakingChange = matches[3] == "!"
+		cm.Description = matches[4]
+	}
+	cm.format = options.Format
+	return nil
+}
+
+// formatHeader returns the header of cm in its format with the given parts,
+// after the autosquash prefix.
+func (cm *CommitMessage) formatHeader(commitType, scope, description string) string {
+	switch cm.format {
+	case Kernel:
+		return scope + ": " + description
+	case Gitmoji:
+		return cm.Gitmoji + " " + description
+	}
+	header := commitType
+	if cm.Gitmoji != "" {
+		header = cm.Gitmoji + " " + header
+	}
+	if scope != "" {
+		header += "(" + scope + ")"
+	}
+	if cm.BreakingChange {
+		header += "!"
+	}
+	return header + ": " + description
+}
+
+// isValidCommitType reports whether commitType is one of types, or of the
+// Conventional Commits types when types is nil, ignoring case so that type
+// rules such as lowerCase can report and fix "Feat".
+func isValidCommitType(commitType string, types []string) bool {
+	if types == nil {
+		types = rules.ConventionalCommitTypes
+	}
+	return slices.ContainsFunc(types, func(t string) bool { return strings.EqualFold(t, commitType) })
+}
+
+func isGitmoji(text string) bool {
+	return gitmojiShortcode.MatchString(text) || rules.IsEmoji(text)
+}
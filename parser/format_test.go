@@ -0,0 +1,103 @@
+package parser
+
+import "testing"
+
+func TestParseHeaderFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    HeaderFormat
+		wantErr bool
+	}{
+		{"conventional", Conventional, false},
+		{"Kernel", Kernel, false},
+		{"gitmoji", Gitmoji, false},
+		{"angular", Conventional, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHeaderFormat(tt.name)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseHeaderFormat(%q) = %v, %v, want %v, wantErr %v", tt.name, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCommitMessageFormats(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		options     ParseOptions
+		wantType    string
+		wantScope   string
+		wantGitmoji string
+		wantDesc    string
+		wantErr     bool
+	}{
+		{name: "custom types", message: "feature: add login", options: ParseOptions{Types: []string{"feature", "bugfix"}}, wantType: "feature", wantDesc: "add login"},
+		{name: "type outside custom types", message: "chore: bump", options: ParseOptions{Types: []string{"feature", "bugfix"}}, wantErr: true},
+		{name: "kernel subsystem", message: "drm/i915: fix GPU hang", options: ParseOptions{Format: Kernel}, wantScope: "drm/i915", wantDesc: "fix GPU hang"},
+		{name: "kernel nested subsystems", message: "net: ipv4: tcp: fix: handle zero window", options: ParseOptions{Format: Kernel}, wantScope: "net: ipv4: tcp: fix", wantDesc: "handle zero window"},
+		{name: "kernel fixup", message: "fixup! arm64: dts: add board", options: ParseOptions{Format: Kernel}, wantScope: "arm64: dts", wantDesc: "add board"},
+		{name: "kernel without subsystem", message: "fix GPU hang", options: ParseOptions{Format: Kernel}, wantErr: true},
+		{name: "kernel subsystem with spaces", message: "my driver: fix", options: ParseOptions{Format: Kernel}, wantErr: true},
+		{name: "gitmoji emoji", message: "✨ Add login", options: ParseOptions{Format: Gitmoji}, wantGitmoji: "✨", wantDesc: "Add login"},
+		{name: "gitmoji emoji with variation selector", message: "♻️ Refactor auth", options: ParseOptions{Format: Gitmoji}, wantGitmoji: "♻️", wantDesc: "Refactor auth"},
+		{name: "gitmoji shortcode", message: ":bug: Fix crash", options: ParseOptions{Format: Gitmoji}, wantGitmoji: ":bug:", wantDesc: "Fix crash"},
+		{name: "gitmoji without emoji", message: "Fix crash", options: ParseOptions{Format: Gitmoji}, wantErr: true},
+		{name: "gitmoji conventional header", message: "fix: crash", options: ParseOptions{Format: Gitmoji}, wantErr: true},
+		{name: "conventional with gitmoji", message: "✨ feat(auth): add login", options: ParseOptions{Gitmoji: true}, wantGitmoji: "✨", wantType: "feat", wantScope: "auth", wantDesc: "add login"},
+		{name: "conventional with shortcode", message: ":bug: fix: handle crash", options: ParseOptions{Gitmoji: true}, wantGitmoji: ":bug:", wantType: "fix", wantDesc: "handle crash"},
+		{name: "conventional fixup with gitmoji", message: "fixup! ✨ feat: add login", options: ParseOptions{Gitmoji: true}, wantGitmoji: "✨", wantType: "feat", wantDesc: "add login"},
+		{name: "conventional gitmoji without type", message: ":sparkles: add login", options: ParseOptions{Gitmoji: true}, wantErr: true},
+		{name: "conventional gitmoji not allowed", message: "✨ feat: add login", wantErr: true},
+		{name: "conventional shortcode not allowed", message: ":bug: fix: handle crash", wantErr: true},
+		{name: "conventional word before type", message: "wip feat: add login", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommitMessageWithOptions(tt.message, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCommitMessageWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type != tt.wantType || got.Scope != tt.wantScope || got.Gitmoji != tt.wantGitmoji || got.Description != tt.wantDesc {
+				t.Errorf("ParseCommitMessageWithOptions() = type %q, scope %q, gitmoji %q, description %q, want %q, %q, %q, %q",
+					got.Type, got.Scope, got.Gitmoji, got.Description, tt.wantType, tt.wantScope, tt.wantGitmoji, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestFixWithRulesFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		format  HeaderFormat
+		want    string
+	}{
+		{"kernel", "net: ipv4: fix sеrver\n\nBody", Kernel, "net: ipv4: fix server\n\nBody"},
+		{"gitmoji", ":bug: Fix sеrver", Gitmoji, ":bug: Fix server"},
+		{"conventional with gitmoji", "fixup! 🐛 fix!: sеrver", Conventional, "fixup! 🐛 fix!: server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := ParseCommitMessageWithOptions(tt.message, ParseOptions{Format: tt.format, Gitmoji: true})
+			if err != nil {
+				t.Fatalf("ParseCommitMessageWithOptions() error = %v", err)
+			}
+			got, err := message.FixWithRules(nil, nil, []string{"noMixedScriptWords"}, nil, nil)
+			if err != nil {
+				t.Fatalf("FixWithRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FixWithRules() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,53 +3,122 @@ package parser
 import (
 	"fmt"
 	"regexp"
-	"slices"
 	"strings"
 
 	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+	"golang.org/x/text/unicode/norm"
 )
 
-const scissorsLine = "# ------------------------ >8 ------------------------"
+const (
+	scissorsLine  = "# ------------------------ >8 ------------------------"
+	byteOrderMark = "\ufeff"
+)
 
 // CommitMessage represents a parsed commit message
 type CommitMessage struct {
+	// Header is the whole first line, e.g. "feat(scope)!: description".
+	Header         string
 	Type           string
 	Scope          string
 	BreakingChange bool
 	Description    string
 	Body           string
+	// RawBody is everything after the header line, untrimmed, so the
+	// header/body separator and leading indentation are preserved. Body
+	// rules validate the trimmed Body; RawBody is used for line lengths and
+	// fixes.
+	RawBody string
+	// Raw is the whole message with Git comment lines removed but otherwise
+	// as written, before line ending and Unicode normalization, so
+	// whole-message rules can report text that parsing silently cleans up.
+	Raw string
+	// Autosquash is the "fixup! ", "squash! " or "amend! " prefix, possibly
+	// repeated, of a commit made with git commit --fixup or --squash, or "".
+	// Type and the other header fields describe the header that follows it.
+	Autosquash string
+	// Gitmoji is the emoji or :shortcode: that starts a gitmoji header, or
+	// that precedes the type of a conventional one.
+	Gitmoji string
+	// Footers are the trailers found in the last paragraph of the body.
+	Footers []Footer
+	// References are the JIRA, GitHub and GitLab style issue references
+	// found in the message.
+	References []Reference
+	// Branch is the branch the commit is made on. It is not part of the
+	// message; callers set it for rules that check the message against the
+	// branch.
+	Branch string
+	// Author is the commit author as "Name <email>", set by callers like
+	// Branch, for rules that check sign-offs.
+	Author string
+
+	// format is the header format the message was parsed with.
+	format HeaderFormat
 }
 
-// ParseCommitMessage parses a commit message into its components
+// ParseOptions configures how a commit message is parsed.
+type ParseOptions struct {
+	// Normalization is the Unicode normalization form the message is
+	// converted to before parsing. The zero value is NFC.
+	Normalization norm.Form
+	// SkipNormalization disables Unicode normalization.
+	SkipNormalization bool
+	// Format is the header format; the zero value is Conventional.
+	Format HeaderFormat
+	// Types lists the commit types allowed in Conventional headers; nil
+	// allows rules.ConventionalCommitTypes.
+	Types []string
+	// Gitmoji allows an emoji or :shortcode: before the type of Conventional
+	// headers, e.g. "✨ feat: add login".
+	Gitmoji bool
+}
+
+// ParseCommitMessage parses a commit message into its components after
+// normalizing it to NFC
 func ParseCommitMessage(message string) (*CommitMessage, error) {
-	message = removeCommentLines(message)
+	return ParseCommitMessageWithOptions(message, ParseOptions{})
+}
+
+// ParseCommitMessageWithOptions parses a commit message into its components
+func ParseCommitMessageWithOptions(message string, options ParseOptions) (*CommitMessage, error) {
+	raw := removeCommentLines(message)
+	message = normalizeLineEndings(raw)
+	if !options.SkipNormalization {
+		message = options.Normalization.String(message)
+	}
 	lines := strings.SplitN(message, "\n", 2)
 	header := lines[0]
 
-	// Parse header
-	headerPattern := regexp.MustCompile(`^(\w+)(?:\(([\w-]+(?:/[\w-]+)*)\))?(!)?: (.+)$`)
-	matches := headerPattern.FindStringSubmatch(header)
-	if matches == nil {
-		return nil, fmt.Errorf("invalid commit message format")
+	body, rawBody := "", ""
+	if len(lines) > 1 {
+		rawBody = lines[1]
+		body = strings.TrimSpace(rawBody)
 	}
 
-	commitType := matches[1]
-	if !isValidCommitType(commitType) {
-		return nil, fmt.Errorf("invalid commit type: %s", commitType)
+	cm := &CommitMessage{
+		Header: header,
+		// The autosquash prefix is followed by the header of the commit to
+		// be fixed.
+		Autosquash: rules.AutosquashPattern.FindString(header),
+		Body:       body,
+		RawBody:    rawBody,
+		Raw:        raw,
+		Footers:    parseFooters(body),
 	}
-
-	body := ""
-	if len(lines) > 1 {
-		body = strings.TrimSpace(lines[1])
+	if err := cm.parseHeader(header[len(cm.Autosquash):], options); err != nil {
+		return nil, err
 	}
+	cm.References = cm.findAllReferences()
+	return cm, nil
+}
 
-	return &CommitMessage{
-		Type:           commitType,
-		Scope:          matches[2],
-		BreakingChange: matches[3] == "!",
-		Description:    matches[4],
-		Body:           body,
-	}, nil
+// normalizeLineEndings strips a leading UTF-8 byte order mark and converts
+// CRLF line endings, written by editors configured for Windows, to LF.
+func normalizeLineEndings(message string) string {
+	message = strings.TrimPrefix(message, byteOrderMark)
+	// Removing comment lines may leave the carriage return of the last kept
+	// line at the end of the message.
+	return strings.TrimSuffix(strings.ReplaceAll(message, "\r\n", "\n"), "\r")
 }
 
 // removeCommentLines removes Git's editor hint lines. Git ignores lines whose
@@ -59,7 +128,7 @@ func removeCommentLines(message string) string {
 	lines := strings.Split(message, "\n")
 	filteredLines := make([]string, 0, len(lines))
 	for _, line := range lines {
-		if line == scissorsLine {
+		if strings.TrimSuffix(line, "\r") == scissorsLine {
 			break
 		}
 		if !strings.HasPrefix(line, "#") {
@@ -69,68 +138,226 @@ func removeCommentLines(message string) string {
 	return strings.Join(filteredLines, "\n")
 }
 
-func isValidCommitType(commitType string) bool {
-	return slices.Contains(rules.ConventionalCommitTypes, commitType)
-}
-
 // ValidateWithRules validates different parts of the commit message with specified rules
 func (cm *CommitMessage) ValidateWithRules(typeRules, scopeRules, descriptionRules, bodyRules []string) error {
 	// Validate type
-	if err := validateText(cm.Type, typeRules); err != nil {
+	if err := validateText(cm.Type, typeRules, cm.context("type")); err != nil {
 		return fmt.Errorf("type validation failed: %w", err)
 	}
 
 	// Validate scope
 	if cm.Scope != "" {
-		if err := validateText(cm.Scope, scopeRules); err != nil {
+		if err := validateText(cm.Scope, scopeRules, cm.context("scope")); err != nil {
 			return fmt.Errorf("scope validation failed: %w", err)
 		}
 	}
 
 	// Validate description
-	if err := validateText(cm.Description, descriptionRules); err != nil {
+	if err := validateText(cm.Description, descriptionRules, cm.context("description")); err != nil {
 		return fmt.Errorf("description validation failed: %w", err)
 	}
 
 	// Validate body
-	if err := validateText(cm.Body, bodyRules); err != nil {
+	if err := validateText(cm.Body, bodyRules, cm.context("body")); err != nil {
 		return fmt.Errorf("body validation failed: %w", err)
 	}
 
 	return nil
 }
 
-// ValidateLengthLimits validates strict description and body length limits.
-func (cm *CommitMessage) ValidateLengthLimits(descriptionLimit, bodyLimit int) error {
-	if err := validateLengthLimit("description", cm.Description, descriptionLimit); err != nil {
+// ValidateMessageWithRules validates the whole message, header and body
+// together, with the specified rules.
+func (cm *CommitMessage) ValidateMessageWithRules(messageRules []string) error {
+	if err := validateText(cm.Raw, messageRules, cm.context("message")); err != nil {
+		return fmt.Errorf("message validation failed: %w", err)
+	}
+	return nil
+}
+
+// FixWithRules applies the automatic fixes of the specified rules to their
+// parts of the commit message and returns the fixed message text. Rules that
+// cannot fix text are ignored. Git comment lines are not included.
+//
+// Whole-message rules fix the message as written, so that rules such as
+// noCRLF, noBOM and nfc see what parsing cleans up. The header and body are
+// only rebuilt from the parsed parts when a part rule changes one of them.
+func (cm *CommitMessage) FixWithRules(typeRules, scopeRules, descriptionRules, bodyRules, messageRules []string) (string, error) {
+	commitType, err := fixText(cm.Type, typeRules, cm.context("type"))
+	if err != nil {
+		return "", err
+	}
+	scope, err := fixText(cm.Scope, scopeRules, cm.context("scope"))
+	if err != nil {
+		return "", err
+	}
+	description, err := fixText(cm.Description, descriptionRules, cm.context("description"))
+	if err != nil {
+		return "", err
+	}
+	rawBody, err := fixText(cm.RawBody, bodyRules, cm.context("body"))
+	if err != nil {
+		return "", err
+	}
+
+	message := cm.Raw
+	if commitType != cm.Type || scope != cm.Scope || description != cm.Description || rawBody != cm.RawBody {
+		message = cm.Autosquash + cm.formatHeader(commitType, scope, description)
+		if strings.Contains(cm.Raw, "\n") {
+			message += "\n" + rawBody
+		}
+	}
+	return fixText(message, messageRules, cm.context("message"))
+}
+
+func fixText(text string, ruleNames []string, ctx rules.Context) (string, error) {
+	for _, ruleName := range ruleNames {
+		rule, err := rules.RuleFactory(ruleName)
+		if err != nil {
+			return "", err
+		}
+		switch fixer := rule.(type) {
+		case rules.ContextFixer:
+			text = fixer.FixWithContext(text, ctx)
+		case rules.Fixer:
+			text = fixer.Fix(text)
+		}
+	}
+	return text, nil
+}
+
+// LengthLimits holds strict length limits for the parts of a commit message.
+// A zero limit is disabled.
+type LengthLimits struct {
+	Header         int
+	Description    int
+	Body           int
+	BodyLine       int
+	DescriptionMin int
+	BodyMin        int
+	// Unit selects how lengths are measured; the zero value counts runes.
+	Unit rules.LengthUnit
+}
+
+// ValidateLengths validates all length limits, measured in limits.Unit.
+func (cm *CommitMessage) ValidateLengths(limits LengthLimits) error {
+	if err := validateLengthLimit("header", cm.Header, limits.Header, limits.Unit); err != nil {
+		return err
+	}
+	if err := validateLengthLimit("description", cm.Description, limits.Description, limits.Unit); err != nil {
+		return err
+	}
+	if err := validateLengthLimit("body", cm.Body, limits.Body, limits.Unit); err != nil {
+		return err
+	}
+	if err := validateMinLength("description", cm.Description, limits.DescriptionMin, limits.Unit); err != nil {
 		return err
 	}
-	if err := validateLengthLimit("body", cm.Body, bodyLimit); err != nil {
+	// Trailers such as Signed-off-by do not count towards the minimum.
+	if err := validateMinLength("body", cm.bodyWithoutFooters(), limits.BodyMin, limits.Unit); err != nil {
 		return err
 	}
+	return cm.ValidateBodyLineLength(limits.BodyLine, limits.Unit)
+}
+
+// bodyWithoutFooters returns the body without its footer block.
+func (cm *CommitMessage) bodyWithoutFooters() string {
+	lines := strings.Split(cm.Body, "\n")
+	return strings.TrimSpace(strings.Join(lines[:rules.TrailerBlockStart(lines)], "\n"))
+}
+
+// ValidateLengthLimits validates strict description and body length limits.
+func (cm *CommitMessage) ValidateLengthLimits(descriptionLimit, bodyLimit int) error {
+	return cm.ValidateLengths(LengthLimits{Description: descriptionLimit, Body: bodyLimit})
+}
+
+// ValidateHeaderLengthLimit validates a strict length limit for the whole
+// header, including the type, scope and breaking-change marker.
+func (cm *CommitMessage) ValidateHeaderLengthLimit(limit int) error {
+	return cm.ValidateLengths(LengthLimits{Header: limit})
+}
+
+// ValidateMinLengths validates strict minimum description and body lengths.
+func (cm *CommitMessage) ValidateMinLengths(descriptionMin, bodyMin int) error {
+	return cm.ValidateLengths(LengthLimits{DescriptionMin: descriptionMin, BodyMin: bodyMin})
+}
+
+func validateMinLength(name, text string, limit int, unit rules.LengthUnit) error {
+	if limit == 0 {
+		return nil
+	}
+	length := unit.Length(text)
+	if length < limit {
+		return fmt.Errorf("%s must be at least %d %s, got %d", name, limit, unit.Noun(), length)
+	}
 	return nil
 }
 
-func validateLengthLimit(name, text string, limit int) error {
+func validateLengthLimit(name, text string, limit int, unit rules.LengthUnit) error {
 	if limit == 0 {
 		return nil
 	}
-	length := len([]rune(text))
+	length := unit.Length(text)
 	if length > limit {
-		return fmt.Errorf("%s must be no longer than %d characters, got %d", name, limit, length)
+		return fmt.Errorf("%s must be no longer than %d %s, got %d", name, limit, unit.Noun(), length)
 	}
 	return nil
 }
 
-func validateText(text string, ruleNames []string) error {
+// ValidateBodyLineLength validates that no body or footer line is longer than
+// limit, measured in unit. Lines that are only a URL, indented or fenced code
+// blocks and footer lines are exempt. A zero limit disables the check.
+func (cm *CommitMessage) ValidateBodyLineLength(limit int, unit rules.LengthUnit) error {
+	if limit == 0 {
+		return nil
+	}
+	lines := strings.Split(cm.RawBody, "\n")
+	footerStart := rules.TrailerBlockStart(lines)
+	inFence := false
+	for i, line := range lines {
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence || i >= footerStart || isIndentedCode(line) || urlLinePattern.MatchString(line) {
+			continue
+		}
+		if length := unit.Length(line); length > limit {
+			// Line numbers count from the header, which is line 1.
+			return fmt.Errorf("body line %d must be no longer than %d %s, got %d", i+2, limit, unit.Noun(), length)
+		}
+	}
+	return nil
+}
+
+var (
+	fencePattern   = regexp.MustCompile("^\\s*(?:```|~~~)")
+	urlLinePattern = regexp.MustCompile(`^\s*(?:[-*>]\s+|\[[^\]]*\]:?\s+)?<?[a-zA-Z][a-zA-Z0-9+.-]*://\S+>?\s*$`)
+)
+
+func isIndentedCode(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+func validateText(text string, ruleNames []string, ctx rules.Context) error {
 	for _, ruleName := range ruleNames {
 		rule, err := rules.RuleFactory(ruleName)
 		if err != nil {
 			return err
 		}
-		if err := rule.Validate(text); err != nil {
+		if contextRule, ok := rule.(rules.ContextRule); ok {
+			err = contextRule.ValidateWithContext(text, ctx)
+		} else {
+			err = rule.Validate(text)
+		}
+		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// context returns the parts of the message that context rules depend on when
+// checking part.
+func (cm *CommitMessage) context(part string) rules.Context {
+	return rules.Context{Part: part, Type: cm.Type, Scope: cm.Scope, Branch: cm.Branch, Author: cm.Author}
+}
@@ -1,8 +1,12 @@
 package parser
 
 import (
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+	"golang.org/x/text/unicode/norm"
 )
 
 func TestParseCommitMessage(t *testing.T) {
@@ -132,6 +136,14 @@ index 1234567..abcdefg 100644
 			message: "invalid: not a valid type",
 			wantErr: true,
 		},
+		{
+			name:    "commit type in another case",
+			message: "Feat: add login",
+			want: &CommitMessage{
+				Type:        "Feat",
+				Description: "add login",
+			},
+		},
 		{
 			name:    "missing description",
 			message: "feat(scope):",
@@ -142,6 +154,31 @@ index 1234567..abcdefg 100644
 			message: "just some text",
 			wantErr: true,
 		},
+		{
+			name:    "fixup commit",
+			message: "fixup! feat(scope): add new feature",
+			want: &CommitMessage{
+				Type:        "feat",
+				Scope:       "scope",
+				Autosquash:  "fixup! ",
+				Description: "add new feature",
+			},
+		},
+		{
+			name:    "repeated autosquash prefixes",
+			message: "squash! fixup! fix!: repair",
+			want: &CommitMessage{
+				Type:           "fix",
+				BreakingChange: true,
+				Autosquash:     "squash! fixup! ",
+				Description:    "repair",
+			},
+		},
+		{
+			name:    "autosquash prefix without header",
+			message: "fixup! repair",
+			wantErr: true,
+		},
 		{
 			name:    "empty message",
 			message: "",
@@ -179,6 +216,9 @@ index 1234567..abcdefg 100644
 				if got.BreakingChange != tt.want.BreakingChange {
 					t.Errorf("ParseCommitMessage() BreakingChange = %v, want %v", got.BreakingChange, tt.want.BreakingChange)
 				}
+				if got.Autosquash != tt.want.Autosquash {
+					t.Errorf("ParseCommitMessage() Autosquash = %q, want %q", got.Autosquash, tt.want.Autosquash)
+				}
 				if !strings.EqualFold(got.Body, tt.want.Body) {
 					t.Errorf("ParseCommitMessage() Body = %v, want %v", got.Body, tt.want.Body)
 				}
@@ -187,6 +227,144 @@ index 1234567..abcdefg 100644
 	}
 }
 
+func TestParseCommitMessageKeepsRawBody(t *testing.T) {
+	message, err := ParseCommitMessage("feat: add new feature\n\n    indented code\n\n# comment")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+
+	if want := "\n    indented code\n"; message.RawBody != want {
+		t.Errorf("ParseCommitMessage() RawBody = %q, want %q", message.RawBody, want)
+	}
+	if want := "indented code"; message.Body != want {
+		t.Errorf("ParseCommitMessage() Body = %q, want %q", message.Body, want)
+	}
+	if want := "feat: add new feature\n\n    indented code\n"; message.Raw != want {
+		t.Errorf("ParseCommitMessage() Raw = %q, want %q", message.Raw, want)
+	}
+}
+
+func TestValidateMessageWithRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{"blank line before body", "feat: add new feature\n\nBody text", false},
+		{"no body", "feat: add new feature", false},
+		{"body directly after header", "feat: add new feature\nBody text", true},
+		{"comment between header and body", "feat: add new feature\n# comment\n\nBody text", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := ParseCommitMessage(tt.message)
+			if err != nil {
+				t.Fatalf("ParseCommitMessage() error = %v", err)
+			}
+			err = message.ValidateMessageWithRules([]string{"blankLineAfterHeader"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMessageWithRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateWithRulesPassesContext(t *testing.T) {
+	message, err := ParseCommitMessage("feat(kubectl): fix kubectl plugin")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+	if err := message.ValidateWithRules(nil, nil, []string{"spelling(en)"}, nil); err != nil {
+		t.Errorf("ValidateWithRules() error = %v, want scope words to be accepted", err)
+	}
+
+	message, err = ParseCommitMessage("feat(api): fix kubectl plugin")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+	if err := message.ValidateWithRules(nil, nil, []string{"spelling(en)"}, nil); err == nil {
+		t.Error("ValidateWithRules() expected error for a word missing from the dictionary")
+	}
+}
+
+func TestValidateWithRulesBodyTrailers(t *testing.T) {
+	message, err := ParseCommitMessage("chore: bump deps\n\nSigned-off-by: Jane Doe <jane@example.com>")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+	if err := message.ValidateWithRules(nil, nil, nil, []string{"empty"}); err != nil {
+		t.Errorf("ValidateWithRules() error = %v, want a signed-off body to count as empty", err)
+	}
+	if err := message.ValidateWithRules(nil, nil, nil, []string{"notEmpty"}); err == nil {
+		t.Error("ValidateWithRules() error = nil, want a body with only trailers to count as empty")
+	}
+}
+
+func TestParseCommitMessageNormalization(t *testing.T) {
+	decomposed := "feat: \u0438\u0306\n\n\u0438\u0306"
+	tests := []struct {
+		name            string
+		options         ParseOptions
+		wantDescription string
+		wantBody        string
+	}{
+		{"default nfc", ParseOptions{}, "\u0439", "\u0439"},
+		{"nfd", ParseOptions{Normalization: norm.NFD}, "\u0438\u0306", "\u0438\u0306"},
+		{"skip normalization", ParseOptions{SkipNormalization: true}, "\u0438\u0306", "\u0438\u0306"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := ParseCommitMessageWithOptions(decomposed, tt.options)
+			if err != nil {
+				t.Fatalf("ParseCommitMessageWithOptions() error = %v", err)
+			}
+			if message.Description != tt.wantDescription {
+				t.Errorf("Description = %q, want %q", message.Description, tt.wantDescription)
+			}
+			if message.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", message.Body, tt.wantBody)
+			}
+			if message.Raw != decomposed {
+				t.Errorf("Raw = %q, want %q", message.Raw, decomposed)
+			}
+		})
+	}
+}
+
+func TestParseCommitMessageLineEndings(t *testing.T) {
+	message, err := ParseCommitMessage("\ufefffeat(scope): add new feature.\r\n\r\nBody line\r\n# comment\r\n# ------------------------ >8 ------------------------\r\ndiff\r\n")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+
+	if message.Type != "feat" {
+		t.Errorf("Type = %q, want %q", message.Type, "feat")
+	}
+	if message.Description != "add new feature." {
+		t.Errorf("Description = %q, want %q", message.Description, "add new feature.")
+	}
+	if message.Header != "feat(scope): add new feature." {
+		t.Errorf("Header = %q, want %q", message.Header, "feat(scope): add new feature.")
+	}
+	if message.RawBody != "\nBody line" {
+		t.Errorf("RawBody = %q, want %q", message.RawBody, "\nBody line")
+	}
+	if want := "\ufefffeat(scope): add new feature.\r\n\r\nBody line\r"; message.Raw != want {
+		t.Errorf("Raw = %q, want %q", message.Raw, want)
+	}
+	if err := message.ValidateWithRules(nil, nil, []string{"trailingPeriod"}, []string{"trailingPeriod"}); err == nil {
+		t.Error("ValidateWithRules() error = nil, want body trailing period error")
+	}
+	if err := message.ValidateMessageWithRules([]string{"noCRLF"}); err == nil {
+		t.Error("ValidateMessageWithRules() error = nil, want CRLF error")
+	}
+	if err := message.ValidateMessageWithRules([]string{"noBOM"}); err == nil {
+		t.Error("ValidateMessageWithRules() error = nil, want BOM error")
+	}
+}
+
 func TestRemoveCommentLines(t *testing.T) {
 	message := "feat: add new feature\n # This is content, not a Git comment\n# This is a Git comment\n# ------------------------ >8 ------------------------\ndiff --git a/file.go b/file.go\n"
 	want := "feat: add new feature\n # This is content, not a Git comment"
@@ -521,3 +699,248 @@ func TestValidateLengthLimits(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFooters(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Footer
+	}{
+		{"no body", "", nil},
+		{"prose only", "Some prose here.", nil},
+		{
+			name: "footers after prose",
+			body: "Some prose here.\n\nRefs: #123\nSigned-off-by: Jane Doe <jane@example.com>",
+			want: []Footer{{"Refs", "#123"}, {"Signed-off-by", "Jane Doe <jane@example.com>"}},
+		},
+		{
+			name: "hash separator and breaking change",
+			body: "Fixes #42\nBREAKING CHANGE: drop the v1 API",
+			want: []Footer{{"Fixes", "42"}, {"BREAKING CHANGE", "drop the v1 API"}},
+		},
+		{
+			name: "continuation line",
+			body: "BREAKING CHANGE: drop the v1 API\n  and its clients",
+			want: []Footer{{"BREAKING CHANGE", "drop the v1 API\nand its clients"}},
+		},
+		{"prose in last paragraph", "Refs: #123\nand more prose", nil},
+		{"hash after a word that is not an issue token", "See #12 for details", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseFooters(tt.body)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseFooters() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBodyLineLength(t *testing.T) {
+	long := strings.Repeat("a", 73)
+	tests := []struct {
+		name        string
+		rawBody     string
+		limit       int
+		unit        rules.LengthUnit
+		wantErrText string
+	}{
+		{name: "disabled limit", rawBody: "\n" + long, limit: 0},
+		{name: "line at limit", rawBody: "\n" + long[:72], limit: 72},
+		{name: "line over limit", rawBody: "\nshort\n" + long, limit: 72, wantErrText: "body line 4 must be no longer than 72 characters, got 73"},
+		{name: "url line", rawBody: "\nhttps://example.com/" + long, limit: 72},
+		{name: "reference url line", rawBody: "\n[1]: https://example.com/" + long, limit: 72},
+		{name: "prose with url", rawBody: "\nsee https://example.com/" + long, limit: 72, wantErrText: "body line 3 must be no longer than 72 characters, got 97"},
+		{name: "indented code", rawBody: "\n    " + long, limit: 72},
+		{name: "fenced code", rawBody: "\n```\n" + long + "\n```", limit: 72},
+		{name: "after fenced code", rawBody: "\n```\ncode\n```\n" + long, limit: 72, wantErrText: "body line 6 must be no longer than 72 characters, got 73"},
+		{name: "footer value", rawBody: "\nProse.\n\nCo-authored-by: " + long, limit: 72},
+		{name: "wide characters as runes", rawBody: "\n漢字漢字", limit: 4},
+		{name: "wide characters as columns", rawBody: "\n漢字漢字", limit: 4, unit: rules.Columns, wantErrText: "body line 3 must be no longer than 4 columns, got 8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := &CommitMessage{RawBody: tt.rawBody}
+			err := message.ValidateBodyLineLength(tt.limit, tt.unit)
+			if tt.wantErrText == "" {
+				if err != nil {
+					t.Errorf("ValidateBodyLineLength() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrText {
+				t.Errorf("ValidateBodyLineLength() error = %v, want %v", err, tt.wantErrText)
+			}
+		})
+	}
+}
+
+func TestValidateHeaderLengthLimit(t *testing.T) {
+	message, err := ParseCommitMessage("feat(scope)!: add new feature")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+
+	if err := message.ValidateHeaderLengthLimit(29); err != nil {
+		t.Errorf("ValidateHeaderLengthLimit() error = %v, want nil", err)
+	}
+	if err := message.ValidateHeaderLengthLimit(0); err != nil {
+		t.Errorf("ValidateHeaderLengthLimit() error = %v, want nil", err)
+	}
+	want := "header must be no longer than 28 characters, got 29"
+	if err := message.ValidateHeaderLengthLimit(28); err == nil || err.Error() != want {
+		t.Errorf("ValidateHeaderLengthLimit() error = %v, want %v", err, want)
+	}
+}
+
+func TestValidateMinLengths(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        *CommitMessage
+		descriptionMin int
+		bodyMin        int
+		wantErrText    string
+	}{
+		{name: "disabled limits", message: &CommitMessage{Description: "x"}},
+		{name: "description at limit", message: &CommitMessage{Description: "abcde"}, descriptionMin: 5},
+		{name: "description under limit", message: &CommitMessage{Description: "abcd"}, descriptionMin: 5, wantErrText: "description must be at least 5 characters, got 4"},
+		{name: "unicode rune length", message: &CommitMessage{Description: "ЖЖЖЖЖ"}, descriptionMin: 5},
+		{name: "missing body", message: &CommitMessage{Description: "x"}, bodyMin: 1, wantErrText: "body must be at least 1 characters, got 0"},
+		{name: "body at limit", message: &CommitMessage{Body: "body"}, bodyMin: 4},
+		{name: "body at limit before footers", message: &CommitMessage{Body: "body\n\nRefs: #12"}, bodyMin: 4},
+		{name: "only footers", message: &CommitMessage{Body: "Signed-off-by: Jane Doe <jane@example.com>"}, bodyMin: 4, wantErrText: "body must be at least 4 characters, got 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.message.ValidateMinLengths(tt.descriptionMin, tt.bodyMin)
+			if tt.wantErrText == "" {
+				if err != nil {
+					t.Errorf("ValidateMinLengths() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrText {
+				t.Errorf("ValidateMinLengths() error = %v, want %v", err, tt.wantErrText)
+			}
+		})
+	}
+}
+
+func TestValidateLengthsUnits(t *testing.T) {
+	// "й" written as "и" + combining breve, a flag and an emoji with a
+	// skin-tone modifier are three user-perceived characters.
+	description := "и\u0306🇷🇺👍🏽"
+	tests := []struct {
+		name        string
+		unit        rules.LengthUnit
+		limit       int
+		wantErrText string
+	}{
+		{name: "graphemes", unit: rules.Graphemes, limit: 3},
+		{name: "runes", unit: rules.Runes, limit: 3, wantErrText: "description must be no longer than 3 characters, got 6"},
+		{name: "bytes", unit: rules.Bytes, limit: 3, wantErrText: "description must be no longer than 3 bytes, got 20"},
+		{name: "columns", unit: rules.Columns, limit: 5},
+		{name: "columns over limit", unit: rules.Columns, limit: 4, wantErrText: "description must be no longer than 4 columns, got 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := &CommitMessage{Description: description}
+			err := message.ValidateLengths(LengthLimits{Description: tt.limit, Unit: tt.unit})
+			if tt.wantErrText == "" {
+				if err != nil {
+					t.Errorf("ValidateLengths() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrText {
+				t.Errorf("ValidateLengths() error = %v, want %v", err, tt.wantErrText)
+			}
+		})
+	}
+}
+
+func TestFixWithRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string
+		descRules    []string
+		bodyRules    []string
+		messageRules []string
+		want         string
+	}{
+		{
+			name:    "no fixable rules",
+			message: "feat(api)!: add endpoint\n\nBody\n# comment",
+			want:    "feat(api)!: add endpoint\n\nBody",
+		},
+		{
+			name:      "description fix",
+			message:   "fix: restart sеrver",
+			descRules: []string{"noCyrillic", "noMixedScriptWords"},
+			want:      "fix: restart server",
+		},
+		{
+			name:      "body fix keeps layout",
+			message:   "fix: restart\n\n    sеrver.Restart()\n",
+			bodyRules: []string{"noMixedScriptWords"},
+			want:      "fix: restart\n\n    server.Restart()\n",
+		},
+		{
+			name:         "message fix",
+			message:      "fix: restart sеrver\n\nsеrver",
+			messageRules: []string{"noConfusables"},
+			want:         "fix: restart server\n\nserver",
+		},
+		{
+			name:      "fix keeps autosquash prefix",
+			message:   "fixup! fix: restart sеrver",
+			descRules: []string{"noMixedScriptWords"},
+			want:      "fixup! fix: restart server",
+		},
+		{
+			name:         "message fix sees line endings",
+			message:      "\ufefffix: restart\r\n\r\nBody\r\n",
+			messageRules: []string{"noBOM", "noCRLF"},
+			want:         "fix: restart\n\nBody\n",
+		},
+		{
+			name:         "message fix sees unnormalized text",
+			message:      "fix: add cafe\u0301",
+			messageRules: []string{"nfc"},
+			want:         "fix: add caf\u00e9",
+		},
+		{
+			name:         "unfixed message is kept as written",
+			message:      "fix: restart\r\n\r\nBody",
+			messageRules: []string{"noConfusables"},
+			want:         "fix: restart\r\n\r\nBody",
+		},
+		{
+			name:         "message fix with author",
+			message:      "fix: restart server",
+			messageRules: []string{"signedOff"},
+			want:         "fix: restart server\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := ParseCommitMessage(tt.message)
+			if err != nil {
+				t.Fatalf("ParseCommitMessage() error = %v", err)
+			}
+			message.Author = "Jane Doe <jane@example.com>"
+			got, err := message.FixWithRules(nil, nil, tt.descRules, tt.bodyRules, tt.messageRules)
+			if err != nil {
+				t.Fatalf("FixWithRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FixWithRules() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
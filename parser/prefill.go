@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+)
+
+// PrefillTicket adds ticket to a commit message file being prepared by the
+// prepare-commit-msg hook, keeping Git's comment lines. A Conventional header
+// without a scope that parses with options gets the ticket as its scope, as
+// in "feat(TGK-1827): ..."; otherwise, including for an empty message and for
+// the other header formats, a "Refs:" footer is added. Messages that already
+// mention the ticket are returned unchanged.
+func PrefillTicket(message, ticket string, options ParseOptions) string {
+	if strings.Contains(strings.ToLower(removeCommentLines(message)), strings.ToLower(ticket)) {
+		return message
+	}
+
+	lines := strings.Split(message, "\n")
+	end := len(lines)
+	for i, line := range lines {
+		if strings.TrimSuffix(line, "\r") == scissorsLine {
+			end = i
+			break
+		}
+	}
+	// Indexes of the message lines that are not comments, before the scissors.
+	var content []int
+	last := -1
+	for i := range lines[:end] {
+		if strings.HasPrefix(lines[i], "#") {
+			continue
+		}
+		content = append(content, i)
+		if strings.TrimSpace(lines[i]) != "" {
+			last = i
+		}
+	}
+
+	if last < 0 {
+		// An empty message: keep the first line free for the header.
+		if len(content) == 0 || content[0] != 0 {
+			lines = append([]string{""}, lines...)
+		}
+		return insertLines(lines, 1, "", "Refs: "+ticket)
+	}
+
+	header := content[0]
+	if options.Format == Conventional {
+		cm := &CommitMessage{}
+		if cm.parseHeader(lines[header], options) == nil && cm.Scope == "" {
+			lines[header] = cm.formatHeader(cm.Type, ticket, cm.Description)
+			return strings.Join(lines, "\n")
+		}
+	}
+
+	var body []string
+	for _, i := range content[1:] {
+		body = append(body, lines[i])
+	}
+	if rules.TrailerBlockStart(body) < len(body) {
+		return insertLines(lines, last+1, "Refs: "+ticket)
+	}
+	return insertLines(lines, last+1, "", "Refs: "+ticket)
+}
+
+// insertLines inserts extra before lines[at] and joins the result.
+func insertLines(lines []string, at int, extra ...string) string {
+	result := append(append(append([]string{}, lines[:at]...), extra...), lines[at:]...)
+	return strings.Join(result, "\n")
+}
@@ -0,0 +1,108 @@
+package parser
+
+import "testing"
+
+func TestPrefillTicket(t *testing.T) {
+	const comments = "# Please enter the commit message for your changes.\n#\n# On branch feature/TGK-1827-login\n"
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			"empty message from template",
+			"\n" + comments,
+			"\n\nRefs: TGK-1827\n" + comments,
+		},
+		{
+			"empty message without blank first line",
+			comments,
+			"\n\nRefs: TGK-1827\n" + comments,
+		},
+		{
+			"header without scope",
+			"feat: add login\n" + comments,
+			"feat(TGK-1827): add login\n" + comments,
+		},
+		{
+			"breaking header without scope",
+			"feat!: drop v1 login",
+			"feat(TGK-1827)!: drop v1 login",
+		},
+		{
+			"header with scope",
+			"feat(auth): add login\n\nBody text.\n" + comments,
+			"feat(auth): add login\n\nBody text.\n\nRefs: TGK-1827\n" + comments,
+		},
+		{
+			"existing footer block",
+			"feat(auth): add login\n\nSigned-off-by: Jane Doe <jane@example.com>\n",
+			"feat(auth): add login\n\nSigned-off-by: Jane Doe <jane@example.com>\nRefs: TGK-1827\n",
+		},
+		{
+			"not a conventional header",
+			"wip",
+			"wip\n\nRefs: TGK-1827",
+		},
+		{
+			"ticket already present",
+			"feat(tgk-1827): add login\n" + comments,
+			"feat(tgk-1827): add login\n" + comments,
+		},
+		{
+			"ticket only in comments",
+			"fix(auth): crash\n" + comments,
+			"fix(auth): crash\n\nRefs: TGK-1827\n" + comments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PrefillTicket(tt.message, "TGK-1827", ParseOptions{}); got != tt.want {
+				t.Errorf("PrefillTicket() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefillTicketWithOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		options ParseOptions
+		want    string
+	}{
+		{
+			"configured commit type",
+			"deps: bump yaml",
+			ParseOptions{Types: []string{"deps"}},
+			"deps(TGK-1827): bump yaml",
+		},
+		{
+			"type missing from configured types",
+			"feat: add login",
+			ParseOptions{Types: []string{"deps"}},
+			"feat: add login\n\nRefs: TGK-1827",
+		},
+		{
+			"kernel header",
+			"net: fix crash",
+			ParseOptions{Format: Kernel},
+			"net: fix crash\n\nRefs: TGK-1827",
+		},
+		{
+			"gitmoji header",
+			"✨ Add login",
+			ParseOptions{Format: Gitmoji},
+			"✨ Add login\n\nRefs: TGK-1827",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PrefillTicket(tt.message, "TGK-1827", tt.options); got != tt.want {
+				t.Errorf("PrefillTicket() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/AnruKitakaze/commit-msg-guardian/rules"
+)
+
+// Reference is an issue or ticket reference found in a commit message.
+type Reference struct {
+	// ID is the reference as written, e.g. "ABC-123", "#123" or "group/proj#12".
+	ID string
+	// Location is the part of the message it was found in: "scope",
+	// "description", "body" or "footer".
+	Location string
+}
+
+// ReferenceLocations lists the parts of a message references are looked for in.
+var ReferenceLocations = []string{"scope", "description", "body", "footer"}
+
+// FindReferences returns the references matching pattern, in message order.
+func (cm *CommitMessage) FindReferences(pattern *regexp.Regexp) []Reference {
+	lines := strings.Split(cm.Body, "\n")
+	footerStart := rules.TrailerBlockStart(lines)
+	parts := []struct{ location, text string }{
+		{"scope", cm.Scope},
+		{"description", cm.Description},
+		{"body", strings.Join(lines[:footerStart], "\n")},
+		{"footer", strings.Join(lines[footerStart:], "\n")},
+	}
+	var references []Reference
+	for _, part := range parts {
+		for _, id := range rules.FindReferences(part.text, pattern) {
+			references = append(references, Reference{ID: id, Location: part.location})
+		}
+	}
+	return references
+}
+
+// findAllReferences returns the references in any of the built-in formats.
+func (cm *CommitMessage) findAllReferences() []Reference {
+	var references []Reference
+	for _, name := range []string{"jira", "gitlab", "github"} {
+		pattern, _ := rules.ParseReferencePattern(name)
+		references = append(references, cm.FindReferences(pattern)...)
+	}
+	order := func(location string) int { return slices.Index(ReferenceLocations, location) }
+	slices.SortStableFunc(references, func(a, b Reference) int { return order(a.Location) - order(b.Location) })
+	return references
+}
+
+// ReferenceRequirement requires commits to reference a ticket.
+type ReferenceRequirement struct {
+	// Pattern is "jira", "github", "gitlab" or a regular expression; an empty
+	// pattern disables the requirement.
+	Pattern string
+	// Locations lists where the reference must be: "scope", "description",
+	// "body" or "footer". Any location is accepted when it is empty.
+	Locations []string
+	// Types lists the commit types that require a reference. Every type does
+	// when it is empty.
+	Types []string
+}
+
+// ValidateReferences validates that the message references a ticket as
+// required.
+func (cm *CommitMessage) ValidateReferences(requirement ReferenceRequirement) error {
+	if requirement.Pattern == "" {
+		return nil
+	}
+	if len(requirement.Types) > 0 && !isValidCommitType(cm.Type, requirement.Types) {
+		return nil
+	}
+	pattern, err := rules.ParseReferencePattern(requirement.Pattern)
+	if err != nil {
+		return err
+	}
+	locations := requirement.Locations
+	if len(locations) == 0 {
+		locations = ReferenceLocations
+	}
+	for _, location := range locations {
+		if !slices.Contains(ReferenceLocations, location) {
+			return fmt.Errorf("unknown reference location: %s", location)
+		}
+	}
+	for _, reference := range cm.FindReferences(pattern) {
+		if slices.Contains(locations, reference.Location) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s commits must reference a ticket matching %s in the %s", cm.Type, requirement.Pattern, strings.Join(locations, " or "))
+}
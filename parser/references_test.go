@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommitMessageReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Reference
+	}{
+		{"no references", "feat: add login", nil},
+		{"jira scope", "feat(TGK-1827): add login", []Reference{{"TGK-1827", "scope"}}},
+		{"github description", "fix: crash on start (#42)", []Reference{{"#42", "description"}}},
+		{"gitlab body", "fix: crash\n\nSee group/proj#12 for details.", []Reference{{"group/proj#12", "body"}}},
+		{
+			"footers",
+			"fix: crash\n\nBody text.\n\nRefs: ABC-1\nCloses #7",
+			[]Reference{{"ABC-1", "footer"}, {"#7", "footer"}},
+		},
+		{
+			"several locations",
+			"feat(ABC-1): add login for #3\n\nRefs: ABC-2",
+			[]Reference{{"ABC-1", "scope"}, {"#3", "description"}, {"ABC-2", "footer"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := ParseCommitMessage(tt.message)
+			if err != nil {
+				t.Fatalf("ParseCommitMessage() error = %v", err)
+			}
+			if !reflect.DeepEqual(message.References, tt.want) {
+				t.Errorf("References = %v, want %v", message.References, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		requirement ReferenceRequirement
+		wantErr     bool
+	}{
+		{"disabled", "feat: add login", ReferenceRequirement{}, false},
+		{"jira anywhere", "feat: add login\n\nRefs: ABC-12", ReferenceRequirement{Pattern: "jira"}, false},
+		{"jira missing", "feat: add login", ReferenceRequirement{Pattern: "jira"}, true},
+		{"encoding is not jira", "feat: add UTF-8 support\n\nHash with SHA-256.", ReferenceRequirement{Pattern: "jira"}, true},
+		{"jira in scope", "feat(ABC-12): add login", ReferenceRequirement{Pattern: "jira", Locations: []string{"scope"}}, false},
+		{"jira in wrong location", "feat: add login\n\nRefs: ABC-12", ReferenceRequirement{Pattern: "jira", Locations: []string{"scope"}}, true},
+		{"github in footer", "fix: crash\n\nFixes #12", ReferenceRequirement{Pattern: "github", Locations: []string{"footer"}}, false},
+		{"gitlab is not github", "fix: crash\n\nRefs: group/proj#12", ReferenceRequirement{Pattern: "github"}, true},
+		{"gitlab", "fix: crash\n\nRefs: group/proj#12", ReferenceRequirement{Pattern: "gitlab"}, false},
+		{"custom pattern", "fix: crash [T-9]", ReferenceRequirement{Pattern: `\[T-\d+\]`}, false},
+		{"type without requirement", "docs: update readme", ReferenceRequirement{Pattern: "jira", Types: []string{"feat", "fix"}}, false},
+		{"type with requirement", "fix: crash", ReferenceRequirement{Pattern: "jira", Types: []string{"feat", "fix"}}, true},
+		{"type with requirement in another case", "FEAT: add login", ReferenceRequirement{Pattern: "jira", Types: []string{"feat", "fix"}}, true},
+		{"invalid pattern", "fix: crash", ReferenceRequirement{Pattern: "[a-"}, true},
+		{"unknown location", "fix(ABC-1): crash", ReferenceRequirement{Pattern: "jira", Locations: []string{"title"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := ParseCommitMessage(tt.message)
+			if err != nil {
+				t.Fatalf("ParseCommitMessage() error = %v", err)
+			}
+			err = message.ValidateReferences(tt.requirement)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateReferences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReferencesMessage(t *testing.T) {
+	message, err := ParseCommitMessage("feat: add login")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+	err = message.ValidateReferences(ReferenceRequirement{Pattern: "jira", Locations: []string{"scope", "footer"}})
+	want := "feat commits must reference a ticket matching jira in the scope or footer"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateReferences() error = %v, want %v", err, want)
+	}
+}
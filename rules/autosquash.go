@@ -0,0 +1,24 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AutosquashPattern matches the possibly repeated "fixup! ", "squash! " and
+// "amend! " prefixes that git commit --fixup and --squash add to a header.
+var AutosquashPattern = regexp.MustCompile(`^(?:(?:fixup|squash|amend)! )+`)
+
+// NoFixupRule prevents the "fixup! ", "squash! " and "amend! " commits made
+// by git commit --fixup and --squash, which are meant to be squashed by
+// git rebase --autosquash before they reach a shared branch.
+type NoFixupRule struct{}
+
+func (r *NoFixupRule) Validate(text string) error {
+	if prefix := AutosquashPattern.FindString(text); prefix != "" {
+		kind, _, _ := strings.Cut(prefix, "!")
+		return fmt.Errorf("text must not be a %s! commit; squash it with git rebase --autosquash", kind)
+	}
+	return nil
+}
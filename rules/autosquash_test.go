@@ -0,0 +1,28 @@
+package rules
+
+import "testing"
+
+func TestNoFixupRule(t *testing.T) {
+	rule := &NoFixupRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"regular header", "feat: add login", false},
+		{"word in description", "fix: fixup! handling", false},
+		{"empty string", "", false},
+		{"fixup", "fixup! feat: add login", true},
+		{"squash", "squash! feat: add login\n\nMore details", true},
+		{"amend", "amend! feat: add login", true},
+		{"repeated prefix", "fixup! squash! feat: add login", true},
+	}
+
+	runRuleTests(t, "NoFixupRule", rule, tests)
+
+	err := rule.Validate("squash! feat: add login")
+	want := "text must not be a squash! commit; squash it with git rebase --autosquash"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// revertPattern matches the line "git revert" adds to the body of a revert.
+var revertPattern = regexp.MustCompile(`(?m)^This reverts commit [0-9a-f]{7,64}\.?\s*$`)
+
+// NotEmptyRule requires text that is not blank, e.g. to require a body. A
+// body with only trailers, such as "Signed-off-by:", counts as empty.
+type NotEmptyRule struct{}
+
+func (r *NotEmptyRule) Validate(text string) error {
+	return r.ValidateWithContext(text, Context{})
+}
+
+func (r *NotEmptyRule) ValidateWithContext(text string, ctx Context) error {
+	if strings.TrimSpace(bodyText(text, ctx)) == "" {
+		return fmt.Errorf("text must not be empty")
+	}
+	return nil
+}
+
+// EmptyRule requires blank text, e.g. to forbid a body. Trailers such as
+// "Signed-off-by:" are still allowed in a body.
+type EmptyRule struct{}
+
+func (r *EmptyRule) Validate(text string) error {
+	return r.ValidateWithContext(text, Context{})
+}
+
+func (r *EmptyRule) ValidateWithContext(text string, ctx Context) error {
+	if strings.TrimSpace(bodyText(text, ctx)) != "" {
+		return fmt.Errorf("text must be empty")
+	}
+	return nil
+}
+
+// bodyText returns text without its trailer block when it is a body, so that
+// rules about the body's content ignore trailers.
+func bodyText(text string, ctx Context) string {
+	if ctx.Part == "body" {
+		return withoutTrailers(text)
+	}
+	return text
+}
+
+// RevertsCommitRule requires a "This reverts commit <sha>." line as written by
+// git revert, so the reverted commit can be found from the revert.
+type RevertsCommitRule struct{}
+
+func (r *RevertsCommitRule) Validate(text string) error {
+	if !revertPattern.MatchString(text) {
+		return fmt.Errorf("text must contain a \"This reverts commit <sha>.\" line")
+	}
+	return nil
+}
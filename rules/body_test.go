@@ -0,0 +1,86 @@
+package rules
+
+import "testing"
+
+func TestNotEmptyRule(t *testing.T) {
+	rule := &NotEmptyRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"text", "Explain why", false},
+		{"empty string", "", true},
+		{"only whitespace", " \n\t", true},
+	}
+
+	runRuleTests(t, "NotEmptyRule", rule, tests)
+}
+
+func TestEmptyRule(t *testing.T) {
+	rule := &EmptyRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"empty string", "", false},
+		{"only whitespace", " \n\t", false},
+		{"text", "Explain why", true},
+	}
+
+	runRuleTests(t, "EmptyRule", rule, tests)
+}
+
+func TestBodyRulesIgnoreTrailers(t *testing.T) {
+	body := Context{Part: "body"}
+	signedOff := "Signed-off-by: Jane Doe <jane@example.com>"
+	withText := "Explain why.\n\nRefs: ABC-1\n" + signedOff
+
+	if err := (&EmptyRule{}).ValidateWithContext(signedOff, body); err != nil {
+		t.Errorf("EmptyRule: body with only trailers error = %v, want nil", err)
+	}
+	if err := (&EmptyRule{}).ValidateWithContext(withText, body); err == nil {
+		t.Error("EmptyRule: body with text error = nil, want error")
+	}
+	if err := (&NotEmptyRule{}).ValidateWithContext(signedOff, body); err == nil {
+		t.Error("NotEmptyRule: body with only trailers error = nil, want error")
+	}
+	if err := (&NotEmptyRule{}).ValidateWithContext(withText, body); err != nil {
+		t.Errorf("NotEmptyRule: body with text error = %v, want nil", err)
+	}
+	if err := (&MinWordsRule{Min: 3}).ValidateWithContext(withText, body); err == nil {
+		t.Error("MinWordsRule: trailer words must not be counted")
+	}
+	// Git does not treat '#' as a separator, so only issue tokens use it.
+	if err := (&NotEmptyRule{}).ValidateWithContext("See #12 for details", body); err != nil {
+		t.Errorf("NotEmptyRule: body referencing an issue error = %v, want nil", err)
+	}
+	if err := (&EmptyRule{}).ValidateWithContext("Fixes #12", body); err != nil {
+		t.Errorf("EmptyRule: body with only an issue trailer error = %v, want nil", err)
+	}
+	// Outside a body, a trailer-like line is text like any other.
+	if err := (&EmptyRule{}).ValidateWithContext(signedOff, Context{Part: "message"}); err == nil {
+		t.Error("EmptyRule: message error = nil, want error")
+	}
+}
+
+func TestRevertsCommitRule(t *testing.T) {
+	rule := &RevertsCommitRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"git revert body", "This reverts commit 0c5f0b3a9d1e7f2b4c6d8e0a1b3c5d7e9f1a2b3c.", false},
+		{"abbreviated sha", "This reverts commit 0c5f0b3.", false},
+		{"after explanation", "It broke the login form.\n\nThis reverts commit 0c5f0b3.", false},
+		{"without period", "This reverts commit 0c5f0b3", false},
+		{"empty string", "", true},
+		{"missing sha", "This reverts commit.", true},
+		{"short sha", "This reverts commit 0c5f.", true},
+		{"not at line start", "Note: This reverts commit 0c5f0b3.", true},
+	}
+
+	runRuleTests(t, "RevertsCommitRule", rule, tests)
+}
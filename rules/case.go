@@ -0,0 +1,220 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Letters without case, such as Han or Arabic, count as lower case in the
+// identifier case patterns, so that they pass in any of them but never start
+// a PascalCase word.
+var (
+	kebabCasePattern  = regexp.MustCompile(`^[\p{Ll}\p{Lo}\d]+(?:-[\p{Ll}\p{Lo}\d]+)*$`)
+	snakeCasePattern  = regexp.MustCompile(`^[\p{Ll}\p{Lo}\d]+(?:_[\p{Ll}\p{Lo}\d]+)*$`)
+	camelCasePattern  = regexp.MustCompile(`^[\p{Ll}\p{Lo}][\p{L}\d]*$`)
+	pascalCasePattern = regexp.MustCompile(`^\p{Lu}[\p{L}\d]*$`)
+)
+
+// LowerCaseRule prevents upper case letters.
+type LowerCaseRule struct{}
+
+func (r *LowerCaseRule) Validate(text string) error {
+	if strings.IndexFunc(text, unicode.IsUpper) >= 0 {
+		return fmt.Errorf("text must be lower case")
+	}
+	return nil
+}
+
+// Fix converts text to lower case.
+func (r *LowerCaseRule) Fix(text string) string {
+	return strings.ToLower(text)
+}
+
+// UpperCaseRule prevents lower case letters.
+type UpperCaseRule struct{}
+
+func (r *UpperCaseRule) Validate(text string) error {
+	if strings.IndexFunc(text, unicode.IsLower) >= 0 {
+		return fmt.Errorf("text must be upper case")
+	}
+	return nil
+}
+
+// Fix converts text to upper case.
+func (r *UpperCaseRule) Fix(text string) string {
+	return strings.ToUpper(text)
+}
+
+// SentenceCaseRule requires the first letter to be upper case and the rest
+// lower case.
+type SentenceCaseRule struct{}
+
+func (r *SentenceCaseRule) Validate(text string) error {
+	if r.Fix(text) != text {
+		return fmt.Errorf("text must be sentence case")
+	}
+	return nil
+}
+
+// Fix converts the first letter to upper case and the rest to lower case.
+func (r *SentenceCaseRule) Fix(text string) string {
+	index := strings.IndexFunc(text, unicode.IsLetter)
+	if index < 0 {
+		return text
+	}
+	char, size := utf8.DecodeRuneInString(text[index:])
+	return text[:index] + string(unicode.ToUpper(char)) + strings.ToLower(text[index+size:])
+}
+
+// StartLowerCaseRule requires the first letter to be lower case.
+type StartLowerCaseRule struct{}
+
+func (r *StartLowerCaseRule) Validate(text string) error {
+	if r.Fix(text) != text {
+		return fmt.Errorf("text must start with a lower case letter")
+	}
+	return nil
+}
+
+// Fix converts the first letter to lower case.
+func (r *StartLowerCaseRule) Fix(text string) string {
+	index := strings.IndexFunc(text, unicode.IsLetter)
+	if index < 0 {
+		return text
+	}
+	char, size := utf8.DecodeRuneInString(text[index:])
+	return text[:index] + string(unicode.ToLower(char)) + text[index+size:]
+}
+
+// KebabCaseRule requires lower case words joined by hyphens, such as
+// "user-profile". Slash-delimited path segments, as in allowPathScope scopes,
+// are checked separately.
+type KebabCaseRule struct{}
+
+func (r *KebabCaseRule) Validate(text string) error {
+	return validateIdentifierCase(text, kebabCasePattern, "kebab-case")
+}
+
+// Fix joins the words of text with hyphens in lower case.
+func (r *KebabCaseRule) Fix(text string) string {
+	return fixIdentifierCase(text, kebabCasePattern, func(words []string) string {
+		return strings.ToLower(strings.Join(words, "-"))
+	})
+}
+
+// SnakeCaseRule requires lower case words joined by underscores, such as
+// "user_profile".
+type SnakeCaseRule struct{}
+
+func (r *SnakeCaseRule) Validate(text string) error {
+	return validateIdentifierCase(text, snakeCasePattern, "snake_case")
+}
+
+// Fix joins the words of text with underscores in lower case.
+func (r *SnakeCaseRule) Fix(text string) string {
+	return fixIdentifierCase(text, snakeCasePattern, func(words []string) string {
+		return strings.ToLower(strings.Join(words, "_"))
+	})
+}
+
+// CamelCaseRule requires words joined without separators, each capitalized
+// except the first, such as "userProfile".
+type CamelCaseRule struct{}
+
+func (r *CamelCaseRule) Validate(text string) error {
+	return validateIdentifierCase(text, camelCasePattern, "camelCase")
+}
+
+// Fix joins the words of text in camelCase.
+func (r *CamelCaseRule) Fix(text string) string {
+	return fixIdentifierCase(text, camelCasePattern, func(words []string) string {
+		return strings.ToLower(words[0]) + joinCapitalized(words[1:])
+	})
+}
+
+// PascalCaseRule requires capitalized words joined without separators, such
+// as "UserProfile".
+type PascalCaseRule struct{}
+
+func (r *PascalCaseRule) Validate(text string) error {
+	return validateIdentifierCase(text, pascalCasePattern, "PascalCase")
+}
+
+// Fix joins the words of text in PascalCase.
+func (r *PascalCaseRule) Fix(text string) string {
+	return fixIdentifierCase(text, pascalCasePattern, joinCapitalized)
+}
+
+func validateIdentifierCase(text string, pattern *regexp.Regexp, style string) error {
+	if text == "" {
+		return nil
+	}
+	for _, segment := range strings.Split(text, "/") {
+		if !pattern.MatchString(segment) {
+			return fmt.Errorf("text must be %s", style)
+		}
+	}
+	return nil
+}
+
+// fixIdentifierCase rejoins the words of each slash-delimited segment of text
+// that doesn't match pattern.
+func fixIdentifierCase(text string, pattern *regexp.Regexp, join func(words []string) string) string {
+	segments := strings.Split(text, "/")
+	for i, segment := range segments {
+		if pattern.MatchString(segment) {
+			continue
+		}
+		if words := identifierWords(segment); len(words) > 0 {
+			segments[i] = join(words)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func joinCapitalized(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		char, size := utf8.DecodeRuneInString(w)
+		b.WriteRune(unicode.ToUpper(char))
+		b.WriteString(strings.ToLower(w[size:]))
+	}
+	return b.String()
+}
+
+// identifierWords splits text into words at separators and case changes:
+// "user_profile", "user-profile", "userProfile" and "UserProfile" all split
+// into "user" and "profile" in some case, and "HTTPServer" into "HTTP" and
+// "Server".
+func identifierWords(text string) []string {
+	var words []string
+	runes := []rune(text)
+	start := -1
+	for i, char := range runes {
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(char) {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(previous) || nextLower {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,79 @@
+package rules
+
+import "testing"
+
+func TestCaseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		text    string
+		wantErr bool
+	}{
+		{"lower case", &LowerCaseRule{}, "feat", false},
+		{"lower case with upper case", &LowerCaseRule{}, "Feat", true},
+		{"lower case cyrillic", &LowerCaseRule{}, "Исправить", true},
+		{"upper case", &UpperCaseRule{}, "API", false},
+		{"upper case with lower case", &UpperCaseRule{}, "Api", true},
+		{"sentence case", &SentenceCaseRule{}, "Add login form", false},
+		{"sentence case with capitals", &SentenceCaseRule{}, "Add Login Form", true},
+		{"sentence case lower case start", &SentenceCaseRule{}, "add login form", true},
+		{"start lower case", &StartLowerCaseRule{}, "add API client", false},
+		{"start lower case after punctuation", &StartLowerCaseRule{}, "`Add` client", true},
+		{"start lower case capitalized", &StartLowerCaseRule{}, "Add API client", true},
+		{"kebab case", &KebabCaseRule{}, "user-profile", false},
+		{"kebab case path", &KebabCaseRule{}, "app/user-profile", false},
+		{"kebab case cyrillic", &KebabCaseRule{}, "профиль-пользователя", false},
+		{"kebab case with capitals", &KebabCaseRule{}, "User-Profile", true},
+		{"kebab case with underscore", &KebabCaseRule{}, "user_profile", true},
+		{"kebab case double hyphen", &KebabCaseRule{}, "user--profile", true},
+		{"snake case", &SnakeCaseRule{}, "user_profile", false},
+		{"snake case with hyphen", &SnakeCaseRule{}, "user-profile", true},
+		{"camel case", &CamelCaseRule{}, "userProfile", false},
+		{"camel case capitalized", &CamelCaseRule{}, "UserProfile", true},
+		{"camel case with hyphen", &CamelCaseRule{}, "user-profile", true},
+		{"pascal case", &PascalCaseRule{}, "UserProfile", false},
+		{"pascal case acronym", &PascalCaseRule{}, "HTTPServer", false},
+		{"pascal case lower case start", &PascalCaseRule{}, "userProfile", true},
+		{"empty string", &KebabCaseRule{}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCaseRulesFix(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Fixer
+		text string
+		want string
+	}{
+		{"lower case", &LowerCaseRule{}, "Feat", "feat"},
+		{"upper case", &UpperCaseRule{}, "api", "API"},
+		{"sentence case", &SentenceCaseRule{}, "add Login Form", "Add login form"},
+		{"start lower case", &StartLowerCaseRule{}, "Add API client", "add API client"},
+		{"kebab case from camel case", &KebabCaseRule{}, "userProfile", "user-profile"},
+		{"kebab case from snake case", &KebabCaseRule{}, "User_Profile", "user-profile"},
+		{"kebab case acronym", &KebabCaseRule{}, "HTTPServer", "http-server"},
+		{"kebab case path", &KebabCaseRule{}, "App/userProfile", "app/user-profile"},
+		{"snake case", &SnakeCaseRule{}, "user-profile", "user_profile"},
+		{"camel case", &CamelCaseRule{}, "user-profile", "userProfile"},
+		{"pascal case", &PascalCaseRule{}, "user_profile", "UserProfile"},
+		{"valid pascal case acronym kept", &PascalCaseRule{}, "HTTPServer", "HTTPServer"},
+		{"empty string", &KebabCaseRule{}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Fix(tt.text); got != tt.want {
+				t.Errorf("Fix(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// confusable is a letter that renders like a letter of another script.
+type confusable struct {
+	char      rune
+	name      string
+	lookalike rune
+}
+
+// confusables lists the Cyrillic and Greek letters that Unicode's
+// confusables.txt maps to a single Latin letter. These are the characters
+// that end up in Latin text typed on a Russian or Greek keyboard layout.
+var confusables = []confusable{
+	{'А', "CYRILLIC CAPITAL LETTER A", 'A'},
+	{'В', "CYRILLIC CAPITAL LETTER VE", 'B'},
+	{'Е', "CYRILLIC CAPITAL LETTER IE", 'E'},
+	{'К', "CYRILLIC CAPITAL LETTER KA", 'K'},
+	{'М', "CYRILLIC CAPITAL LETTER EM", 'M'},
+	{'Н', "CYRILLIC CAPITAL LETTER EN", 'H'},
+	{'О', "CYRILLIC CAPITAL LETTER O", 'O'},
+	{'Р', "CYRILLIC CAPITAL LETTER ER", 'P'},
+	{'С', "CYRILLIC CAPITAL LETTER ES", 'C'},
+	{'Т', "CYRILLIC CAPITAL LETTER TE", 'T'},
+	{'У', "CYRILLIC CAPITAL LETTER U", 'Y'},
+	{'Х', "CYRILLIC CAPITAL LETTER HA", 'X'},
+	{'а', "CYRILLIC SMALL LETTER A", 'a'},
+	{'е', "CYRILLIC SMALL LETTER IE", 'e'},
+	{'о', "CYRILLIC SMALL LETTER O", 'o'},
+	{'р', "CYRILLIC SMALL LETTER ER", 'p'},
+	{'с', "CYRILLIC SMALL LETTER ES", 'c'},
+	{'у', "CYRILLIC SMALL LETTER U", 'y'},
+	{'х', "CYRILLIC SMALL LETTER HA", 'x'},
+	{'Ѕ', "CYRILLIC CAPITAL LETTER DZE", 'S'},
+	{'І', "CYRILLIC CAPITAL LETTER BYELORUSSIAN-UKRAINIAN I", 'I'},
+	{'Ј', "CYRILLIC CAPITAL LETTER JE", 'J'},
+	{'ѕ', "CYRILLIC SMALL LETTER DZE", 's'},
+	{'і', "CYRILLIC SMALL LETTER BYELORUSSIAN-UKRAINIAN I", 'i'},
+	{'ј', "CYRILLIC SMALL LETTER JE", 'j'},
+	{'һ', "CYRILLIC SMALL LETTER SHHA", 'h'},
+	{'ԁ', "CYRILLIC SMALL LETTER KOMI DE", 'd'},
+	{'ԛ', "CYRILLIC SMALL LETTER QA", 'q'},
+	{'ԝ', "CYRILLIC SMALL LETTER WE", 'w'},
+	{'Α', "GREEK CAPITAL LETTER ALPHA", 'A'},
+	{'Β', "GREEK CAPITAL LETTER BETA", 'B'},
+	{'Ε', "GREEK CAPITAL LETTER EPSILON", 'E'},
+	{'Ζ', "GREEK CAPITAL LETTER ZETA", 'Z'},
+	{'Η', "GREEK CAPITAL LETTER ETA", 'H'},
+	{'Ι', "GREEK CAPITAL LETTER IOTA", 'I'},
+	{'Κ', "GREEK CAPITAL LETTER KAPPA", 'K'},
+	{'Μ', "GREEK CAPITAL LETTER MU", 'M'},
+	{'Ν', "GREEK CAPITAL LETTER NU", 'N'},
+	{'Ο', "GREEK CAPITAL LETTER OMICRON", 'O'},
+	{'Ρ', "GREEK CAPITAL LETTER RHO", 'P'},
+	{'Τ', "GREEK CAPITAL LETTER TAU", 'T'},
+	{'Υ', "GREEK CAPITAL LETTER UPSILON", 'Y'},
+	{'Χ', "GREEK CAPITAL LETTER CHI", 'X'},
+	{'ο', "GREEK SMALL LETTER OMICRON", 'o'},
+	{'ν', "GREEK SMALL LETTER NU", 'v'},
+}
+
+var (
+	confusableByChar = map[rune]confusable{}
+	// latinToScript maps a Latin letter to its lookalike in another script,
+	// used to fix Latin letters typed into a Cyrillic or Greek word.
+	latinToScript = map[string]map[rune]rune{"Cyrillic": {}, "Greek": {}}
+)
+
+func init() {
+	for _, c := range confusables {
+		confusableByChar[c.char] = c
+		script := scriptOf(c.char)
+		if _, ok := latinToScript[script][c.lookalike]; !ok {
+			latinToScript[script][c.lookalike] = c.char
+		}
+	}
+}
+
+// scriptOf returns the Unicode script name of a letter, or "" for
+// characters in the Common or Inherited scripts.
+func scriptOf(char rune) string {
+	switch {
+	case unicode.Is(unicode.Latin, char):
+		return "Latin"
+	case unicode.Is(unicode.Cyrillic, char):
+		return "Cyrillic"
+	case unicode.Is(unicode.Greek, char):
+		return "Greek"
+	}
+	for name, table := range unicode.Scripts {
+		if name != "Common" && name != "Inherited" && unicode.Is(table, char) {
+			return name
+		}
+	}
+	return ""
+}
+
+// word is a run of letters and combining marks at a rune offset in a text.
+type word struct {
+	text   string
+	offset int
+}
+
+func splitWords(text string) []word {
+	var words []word
+	var current []rune
+	start := 0
+	position := 0
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, word{text: string(current), offset: start})
+			current = nil
+		}
+	}
+	for _, char := range text {
+		if unicode.IsLetter(char) || unicode.IsMark(char) {
+			if len(current) == 0 {
+				start = position
+			}
+			current = append(current, char)
+		} else {
+			flush()
+		}
+		position++
+	}
+	flush()
+	return words
+}
+
+// wordScripts returns the scripts of the letters in a word, the script with
+// the most letters first.
+func wordScripts(text string) []string {
+	counts := map[string]int{}
+	for _, char := range text {
+		if script := scriptOf(char); script != "" {
+			counts[script]++
+		}
+	}
+	scripts := make([]string, 0, len(counts))
+	for script := range counts {
+		scripts = append(scripts, script)
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		if counts[scripts[i]] != counts[scripts[j]] {
+			return counts[scripts[i]] > counts[scripts[j]]
+		}
+		// Prefer Latin on ties: commit text is more often Latin.
+		return scripts[i] == "Latin" || (scripts[j] != "Latin" && scripts[i] < scripts[j])
+	})
+	return scripts
+}
+
+// NoMixedScriptWordsRule prevents words mixing letters of different scripts,
+// such as a Latin word with a Cyrillic "а" typed on a Russian layout. With
+// ConfusablesOnly, only mixes where every foreign letter is a known lookalike
+// of a letter in the word's main script are reported.
+type NoMixedScriptWordsRule struct {
+	ConfusablesOnly bool
+}
+
+func (r *NoMixedScriptWordsRule) Validate(text string) error {
+	for _, w := range splitWords(text) {
+		scripts := wordScripts(w.text)
+		if len(scripts) < 2 {
+			continue
+		}
+		lookalikes, allConfusable := describeLookalikes(w.text, scripts[0])
+		if r.ConfusablesOnly && !allConfusable {
+			continue
+		}
+		message := fmt.Sprintf("word %q at character %d mixes %s", w.text, w.offset+1, strings.Join(scripts, " and "))
+		if len(lookalikes) > 0 {
+			message += ": " + strings.Join(lookalikes, ", ")
+		}
+		return errors.New(message)
+	}
+	return nil
+}
+
+// describeLookalikes names the letters of a word that are not in the main
+// script and the letters they look like. It also reports whether every such
+// letter has a lookalike.
+func describeLookalikes(text, mainScript string) ([]string, bool) {
+	var lookalikes []string
+	allConfusable := true
+	for _, char := range text {
+		script := scriptOf(char)
+		if script == "" || script == mainScript {
+			continue
+		}
+		target, ok := lookalikeIn(char, mainScript)
+		if !ok {
+			allConfusable = false
+			continue
+		}
+		name := fmt.Sprintf("U+%04X", char)
+		if c, ok := confusableByChar[char]; ok {
+			name += " " + c.name
+		} else if char < unicode.MaxASCII {
+			name += " " + latinLetterName(char)
+		}
+		lookalikes = append(lookalikes, fmt.Sprintf("%q (%s) looks like %s %q", char, name, mainScript, target))
+	}
+	return lookalikes, allConfusable
+}
+
+func latinLetterName(char rune) string {
+	if unicode.IsUpper(char) {
+		return "LATIN CAPITAL LETTER " + string(char)
+	}
+	return "LATIN SMALL LETTER " + string(unicode.ToUpper(char))
+}
+
+// lookalikeIn returns the letter of script that char looks like.
+func lookalikeIn(char rune, script string) (rune, bool) {
+	if script == "Latin" {
+		c, ok := confusableByChar[char]
+		return c.lookalike, ok
+	}
+	latin := char
+	if scriptOf(char) != "Latin" {
+		c, ok := confusableByChar[char]
+		if !ok {
+			return 0, false
+		}
+		latin = c.lookalike
+	}
+	target, ok := latinToScript[script][latin]
+	return target, ok
+}
+
+// Fix replaces the foreign letters of mixed-script words with their
+// lookalikes in the word's main script, where such lookalikes exist.
+func (r *NoMixedScriptWordsRule) Fix(text string) string {
+	runes := []rune(text)
+	for _, w := range splitWords(text) {
+		scripts := wordScripts(w.text)
+		if len(scripts) < 2 {
+			continue
+		}
+		for i, char := range []rune(w.text) {
+			script := scriptOf(char)
+			if script == "" || script == scripts[0] {
+				continue
+			}
+			if target, ok := lookalikeIn(char, scripts[0]); ok {
+				runes[w.offset+i] = target
+			}
+		}
+	}
+	return string(runes)
+}
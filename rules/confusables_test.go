@@ -0,0 +1,70 @@
+package rules
+
+import "testing"
+
+func TestNoMixedScriptWordsRule(t *testing.T) {
+	rule := &NoMixedScriptWordsRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"latin only", "fix server crash", false},
+		{"cyrillic only", "исправить сервер", false},
+		{"separate scripts", "fix сервер", false},
+		{"cyrillic letter in latin word", "fix sеrver", true},
+		{"latin letter in cyrillic word", "исправить cервер", true},
+		{"greek letter in latin word", "fix Οpen", true},
+		{"non-confusable mix", "fixд", true},
+		{"digits between scripts", "v2сервер", false},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "NoMixedScriptWordsRule", rule, tests)
+}
+
+func TestNoConfusablesRule(t *testing.T) {
+	rule := &NoMixedScriptWordsRule{ConfusablesOnly: true}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"latin only", "fix server crash", false},
+		{"cyrillic letter in latin word", "fix sеrver", true},
+		{"latin letter in cyrillic word", "исправить cервер", true},
+		{"non-confusable mix", "fixд", false},
+	}
+
+	runRuleTests(t, "NoConfusablesRule", rule, tests)
+}
+
+func TestNoMixedScriptWordsRuleMessage(t *testing.T) {
+	err := (&NoMixedScriptWordsRule{}).Validate("fix sеrvеr")
+	want := `word "sеrvеr" at character 5 mixes Latin and Cyrillic: 'е' (U+0435 CYRILLIC SMALL LETTER IE) looks like Latin 'e', 'е' (U+0435 CYRILLIC SMALL LETTER IE) looks like Latin 'e'`
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
+
+func TestNoMixedScriptWordsRuleFix(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"cyrillic letters in latin word", "fix sеrvеr сrash", "fix server crash"},
+		{"latin letter in cyrillic word", "исправить cервер", "исправить сервер"},
+		{"capital letters", "Рost", "Post"},
+		{"no lookalike", "fixд", "fixд"},
+		{"unmixed words", "fix сервер", "fix сервер"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&NoMixedScriptWordsRule{}).Fix(tt.text); got != tt.want {
+				t.Errorf("Fix(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+// DenyWordsRule prevents words and phrases, such as "wip" or internal
+// codenames. Terms match whole words case-insensitively in any script, with
+// any run of whitespace matching the spaces of a phrase.
+type DenyWordsRule struct {
+	Terms []string
+}
+
+// parseDenyWords creates a DenyWordsRule. An argument starting with '@' names
+// a file listing one term per line, with blank lines and '#' comments skipped;
+// files are read once, when the rule is created.
+func parseDenyWords(args []string) (Rule, error) {
+	terms, err := loadTerms(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("rule denyWords requires at least one word")
+	}
+	return &DenyWordsRule{Terms: terms}, nil
+}
+
+func (r *DenyWordsRule) Validate(text string) error {
+	normalized := foldWords(text)
+	for _, term := range r.Terms {
+		if containsWord(normalized, foldWords(term)) {
+			return fmt.Errorf("text contains denied word %q", term)
+		}
+	}
+	return nil
+}
+
+// foldWords case-folds text and joins its words with single spaces.
+func foldWords(text string) string {
+	return cases.Fold().String(strings.Join(strings.Fields(text), " "))
+}
+
+// loadTerms expands '@file' arguments into the terms listed in the files.
+func loadTerms(args []string) ([]string, error) {
+	var terms []string
+	for _, arg := range args {
+		path, ok := strings.CutPrefix(arg, "@")
+		if !ok {
+			terms = append(terms, arg)
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading word list: %v", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				terms = append(terms, line)
+			}
+		}
+	}
+	return terms, nil
+}
+
+// containsWord reports whether term occurs in text as a whole word: an
+// occurrence starting or ending with a letter or digit must not continue a
+// longer word.
+func containsWord(text, term string) bool {
+	if term == "" {
+		return false
+	}
+	for offset := 0; ; {
+		index := strings.Index(text[offset:], term)
+		if index < 0 {
+			return false
+		}
+		start := offset + index
+		end := start + len(term)
+		first, _ := utf8.DecodeRuneInString(term)
+		last, _ := utf8.DecodeLastRuneInString(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !(isWordChar(first) && start > 0 && isWordChar(before)) &&
+			!(isWordChar(last) && end < len(text) && isWordChar(after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+}
+
+func isWordChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || unicode.IsMark(char)
+}
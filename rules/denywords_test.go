@@ -0,0 +1,46 @@
+package rules
+
+import "testing"
+
+func TestDenyWordsRule(t *testing.T) {
+	rule, err := parseDenyWords([]string{"wip", "misc", "...", "work in progress", "черновик", "Straße", "@testdata/codenames.txt"})
+	if err != nil {
+		t.Fatalf("parseDenyWords() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"clean text", "Add login form", false},
+		{"denied word", "wip login form", true},
+		{"denied word in upper case", "WIP: login form", true},
+		{"denied word inside a longer word", "Wipe cache on logout", false},
+		{"denied punctuation", "Update...", true},
+		{"denied phrase", "Work  in\tprogress on login", true},
+		{"denied cyrillic word", "Черновик формы", true},
+		{"cyrillic word inside a longer word", "Черновики удалены", false},
+		{"folded case", "Fix STRASSE lookup", true},
+		{"word from file", "Prepare project falcon launch", true},
+		{"single word from file", "Rename Bluebird client", true},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "DenyWordsRule", rule, tests)
+}
+
+func TestDenyWordsRuleMessage(t *testing.T) {
+	rule, err := parseDenyWords([]string{"@testdata/codenames.txt"})
+	if err != nil {
+		t.Fatalf("parseDenyWords() error = %v", err)
+	}
+	err = rule.Validate("Launch bluebird")
+	want := `text contains denied word "bluebird"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+
+	if _, err := parseDenyWords([]string{"@testdata/missing.txt"}); err == nil {
+		t.Error("parseDenyWords() of a missing file error = nil, want error")
+	}
+}
@@ -0,0 +1,25 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidUTF8Rule requires text to be valid UTF-8. Messages written in a legacy
+// encoding such as windows-1251 without git's i18n.commitEncoding set fail it.
+type ValidUTF8Rule struct{}
+
+func (r *ValidUTF8Rule) Validate(text string) error {
+	for offset, char := range text {
+		if char != utf8.RuneError {
+			continue
+		}
+		if _, size := utf8.DecodeRuneInString(text[offset:]); size > 1 {
+			continue
+		}
+		line := strings.Count(text[:offset], "\n") + 1
+		return fmt.Errorf("text is not valid UTF-8: byte 0x%02X at line %d; set i18n.commitEncoding if the message uses another encoding", text[offset], line)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package rules
+
+import "testing"
+
+func TestValidUTF8Rule(t *testing.T) {
+	rule := &ValidUTF8Rule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"ascii", "feat: add x", false},
+		{"cyrillic", "feat: добавить x", false},
+		{"replacement character", "feat: add �", false},
+		{"windows-1251 bytes", "feat: \xe4\xee\xe1\xe0\xe2\xe8\xf2\xfc x", true},
+		{"truncated sequence", "feat: add \xd0", true},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "ValidUTF8Rule", rule, tests)
+
+	err := rule.Validate("feat: add x\n\n\xcf\xf0\xe8\xe2\xe5\xf2")
+	want := "text is not valid UTF-8: byte 0xCF at line 3; set i18n.commitEncoding if the message uses another encoding"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,233 @@
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//go:embed data/gitmojis.txt
+var gitmojiList string
+
+var (
+	gitmojis         = parseGitmojis(gitmojiList)
+	shortcodePattern = regexp.MustCompile(`^:[a-z0-9_+-]+:$`)
+)
+
+// gitmoji is an emoji from https://gitmoji.dev/ and the commit types it fits.
+type gitmoji struct {
+	emoji     string
+	shortcode string
+	types     []string
+}
+
+// gitmojiTable looks gitmojis up by emoji or shortcode, and by the commit
+// type they are added for.
+type gitmojiTable struct {
+	byCode map[string]*gitmoji
+	byType map[string]*gitmoji
+}
+
+// parseGitmojis parses lines of an emoji, its shortcode and the commit types
+// it fits, where a type marked with '*' uses the emoji, skipping blank lines
+// and '#' comments.
+func parseGitmojis(list string) gitmojiTable {
+	table := gitmojiTable{byCode: map[string]*gitmoji{}, byType: map[string]*gitmoji{}}
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		entry := &gitmoji{emoji: fields[0], shortcode: fields[1]}
+		for _, commitType := range fields[2:] {
+			if primary, ok := strings.CutPrefix(commitType, "*"); ok {
+				commitType = primary
+				table.byType[commitType] = entry
+			}
+			entry.types = append(entry.types, commitType)
+		}
+		table.byCode[gitmojiKey(entry.emoji)] = entry
+		table.byCode[entry.shortcode] = entry
+	}
+	return table
+}
+
+// gitmojiKey drops the emoji variation selector, which editors and keyboards
+// add or leave out inconsistently.
+func gitmojiKey(code string) string {
+	return strings.ReplaceAll(code, "\uFE0F", "")
+}
+
+func lookupGitmoji(code string) *gitmoji {
+	return gitmojis.byCode[gitmojiKey(code)]
+}
+
+// findGitmoji returns the byte offsets of the emoji or :shortcode: starting
+// the first line of text after any autosquash prefix, or -1 when there is
+// none.
+func findGitmoji(text string) (int, int) {
+	header, _, _ := strings.Cut(text, "\n")
+	start := len(AutosquashPattern.FindString(header))
+	code, _, _ := strings.Cut(header[start:], " ")
+	if !shortcodePattern.MatchString(code) && !IsEmoji(code) {
+		return -1, -1
+	}
+	return start, start + len(code)
+}
+
+// RequireGitmojiRule requires the header to start with a gitmoji, as an emoji
+// or a shortcode, after any fixup! or squash! prefix.
+type RequireGitmojiRule struct{}
+
+func (r *RequireGitmojiRule) Validate(text string) error {
+	start, end := findGitmoji(text)
+	if start < 0 {
+		return fmt.Errorf("header must start with a gitmoji such as ✨ or :sparkles:")
+	}
+	if code := text[start:end]; lookupGitmoji(code) == nil {
+		return fmt.Errorf("header starts with %s, which is not a gitmoji", code)
+	}
+	return nil
+}
+
+func (r *RequireGitmojiRule) ValidateWithContext(text string, ctx Context) error {
+	return r.Validate(text)
+}
+
+// FixWithContext adds the gitmoji of the commit type to a header without one.
+func (r *RequireGitmojiRule) FixWithContext(text string, ctx Context) string {
+	entry, ok := gitmojis.byType[strings.ToLower(ctx.Type)]
+	if start, _ := findGitmoji(text); start >= 0 || !ok {
+		return text
+	}
+	prefix := len(AutosquashPattern.FindString(text))
+	return text[:prefix] + entry.emoji + " " + text[prefix:]
+}
+
+// NoGitmojiRule prevents a gitmoji at the start of the header.
+type NoGitmojiRule struct{}
+
+func (r *NoGitmojiRule) Validate(text string) error {
+	if start, end := findGitmoji(text); start >= 0 {
+		return fmt.Errorf("header must not start with a gitmoji, found %s", text[start:end])
+	}
+	return nil
+}
+
+// Fix removes the gitmoji and the space after it.
+func (r *NoGitmojiRule) Fix(text string) string {
+	start, end := findGitmoji(text)
+	if start < 0 {
+		return text
+	}
+	if strings.HasPrefix(text[end:], " ") {
+		end++
+	}
+	return text[:start] + text[end:]
+}
+
+// GitmojiTypeRule requires the gitmoji of a header to fit the commit type,
+// such as ✨ for feat or 🐛 for fix. Headers without a gitmoji, and types that
+// no gitmoji fits, are not checked.
+type GitmojiTypeRule struct{}
+
+func (r *GitmojiTypeRule) Validate(text string) error {
+	return nil
+}
+
+func (r *GitmojiTypeRule) ValidateWithContext(text string, ctx Context) error {
+	start, end := findGitmoji(text)
+	expected, ok := gitmojis.byType[strings.ToLower(ctx.Type)]
+	if start < 0 || !ok {
+		return nil
+	}
+	code := text[start:end]
+	entry := lookupGitmoji(code)
+	if entry == nil {
+		return fmt.Errorf("header starts with %s, which is not a gitmoji", code)
+	}
+	for _, commitType := range entry.types {
+		if strings.EqualFold(commitType, ctx.Type) {
+			return nil
+		}
+	}
+	return fmt.Errorf("gitmoji %s does not fit type %s, use %s", code, ctx.Type, sameForm(code, expected))
+}
+
+// FixWithContext replaces a gitmoji that does not fit the commit type with
+// the gitmoji of the type, in the same form.
+func (r *GitmojiTypeRule) FixWithContext(text string, ctx Context) string {
+	if r.ValidateWithContext(text, ctx) == nil {
+		return text
+	}
+	start, end := findGitmoji(text)
+	return text[:start] + sameForm(text[start:end], gitmojis.byType[strings.ToLower(ctx.Type)]) + text[end:]
+}
+
+// sameForm returns the gitmoji as a shortcode when code is one, and as an
+// emoji otherwise.
+func sameForm(code string, entry *gitmoji) string {
+	if shortcodePattern.MatchString(code) {
+		return entry.shortcode
+	}
+	return entry.emoji
+}
+
+// GitmojiUnicodeRule requires a gitmoji to be written as an emoji rather than
+// as a shortcode, which only some tools render.
+type GitmojiUnicodeRule struct{}
+
+func (r *GitmojiUnicodeRule) Validate(text string) error {
+	start, end := findGitmoji(text)
+	if start < 0 || !shortcodePattern.MatchString(text[start:end]) {
+		return nil
+	}
+	code := text[start:end]
+	entry := lookupGitmoji(code)
+	if entry == nil {
+		return fmt.Errorf("header starts with %s, which is not a gitmoji", code)
+	}
+	return fmt.Errorf("gitmoji must be an emoji: use %s instead of %s", entry.emoji, code)
+}
+
+// Fix replaces a known shortcode with its emoji.
+func (r *GitmojiUnicodeRule) Fix(text string) string {
+	start, end := findGitmoji(text)
+	if start < 0 {
+		return text
+	}
+	if entry := lookupGitmoji(text[start:end]); entry != nil {
+		return text[:start] + entry.emoji + text[end:]
+	}
+	return text
+}
+
+// GitmojiShortcodeRule requires a gitmoji to be written as a shortcode rather
+// than as an emoji, for tools and terminals that cannot display emoji.
+type GitmojiShortcodeRule struct{}
+
+func (r *GitmojiShortcodeRule) Validate(text string) error {
+	start, end := findGitmoji(text)
+	if start < 0 || shortcodePattern.MatchString(text[start:end]) {
+		return nil
+	}
+	code := text[start:end]
+	entry := lookupGitmoji(code)
+	if entry == nil {
+		return fmt.Errorf("header starts with %s, which is not a gitmoji", code)
+	}
+	return fmt.Errorf("gitmoji must be a shortcode: use %s instead of %s", entry.shortcode, code)
+}
+
+// Fix replaces a known emoji with its shortcode.
+func (r *GitmojiShortcodeRule) Fix(text string) string {
+	start, end := findGitmoji(text)
+	if start < 0 {
+		return text
+	}
+	if entry := lookupGitmoji(text[start:end]); entry != nil {
+		return text[:start] + entry.shortcode + text[end:]
+	}
+	return text
+}
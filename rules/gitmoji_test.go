@@ -0,0 +1,161 @@
+package rules
+
+import "testing"
+
+func TestGitmojiTable(t *testing.T) {
+	for _, commitType := range []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"} {
+		if _, ok := gitmojis.byType[commitType]; !ok {
+			t.Errorf("no gitmoji for type %s", commitType)
+		}
+	}
+	if entry := lookupGitmoji("♻"); entry == nil || entry.shortcode != ":recycle:" {
+		t.Errorf("lookupGitmoji() without variation selector = %v, want :recycle:", entry)
+	}
+}
+
+func TestRequireGitmojiRule(t *testing.T) {
+	rule := &RequireGitmojiRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"emoji", "✨ feat: add login", false},
+		{"shortcode", ":sparkles: feat: add login", false},
+		{"emoji with variation selector", "♻️ refactor: split parser", false},
+		{"emoji without variation selector", "♻ refactor: split parser", false},
+		{"after fixup prefix", "fixup! 🐛 fix: handle crash", false},
+		{"missing", "feat: add login", true},
+		{"empty string", "", true},
+		{"unknown shortcode", ":unicorn_face: feat: add login", true},
+		{"emoji that is not a gitmoji", "🦄 feat: add login", true},
+		{"emoji in description", "feat: add ✨", true},
+	}
+
+	runRuleTests(t, "RequireGitmojiRule", rule, tests)
+}
+
+func TestRequireGitmojiRuleFix(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		commitType string
+		want       string
+	}{
+		{"adds emoji of type", "feat: add login\n\nBody", "feat", "✨ feat: add login\n\nBody"},
+		{"after fixup prefix", "fixup! fix: handle crash", "fix", "fixup! 🐛 fix: handle crash"},
+		{"keeps existing gitmoji", ":bug: fix: handle crash", "fix", ":bug: fix: handle crash"},
+		{"type without gitmoji", "feature: add login", "feature", "feature: add login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&RequireGitmojiRule{}).FixWithContext(tt.text, Context{Type: tt.commitType}); got != tt.want {
+				t.Errorf("FixWithContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoGitmojiRule(t *testing.T) {
+	rule := &NoGitmojiRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"plain header", "feat: add login", false},
+		{"emoji in description", "feat: add ✨", false},
+		{"empty string", "", false},
+		{"emoji", "✨ feat: add login", true},
+		{"shortcode", ":sparkles: feat: add login", true},
+		{"after squash prefix", "squash! ✨ feat: add login", true},
+	}
+
+	runRuleTests(t, "NoGitmojiRule", rule, tests)
+
+	fixes := map[string]string{
+		"✨ feat: add login":            "feat: add login",
+		"fixup! :bug: fix: crash\n\nX": "fixup! fix: crash\n\nX",
+		"✨":                            "",
+		"feat: add login":              "feat: add login",
+	}
+	for text, want := range fixes {
+		if got := rule.Fix(text); got != want {
+			t.Errorf("Fix(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestGitmojiTypeRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		commitType string
+		want       string
+		wantErr    bool
+	}{
+		{name: "primary emoji", text: "✨ feat: add login", commitType: "feat", want: "✨ feat: add login"},
+		{name: "other fitting emoji", text: "🚑️ fix: patch outage", commitType: "fix", want: "🚑️ fix: patch outage"},
+		{name: "fitting shortcode", text: ":bug: fix: handle crash", commitType: "fix", want: ":bug: fix: handle crash"},
+		{name: "no gitmoji", text: "feat: add login", commitType: "feat", want: "feat: add login"},
+		{name: "type without gitmoji", text: "✨ feature: add login", commitType: "feature", want: "✨ feature: add login"},
+		{name: "wrong emoji", text: "🐛 feat: add login", commitType: "feat", want: "✨ feat: add login", wantErr: true},
+		{name: "type in another case", text: "🐛 Feat: add login", commitType: "Feat", want: "✨ Feat: add login", wantErr: true},
+		{name: "wrong shortcode", text: "fixup! :sparkles: fix: crash", commitType: "fix", want: "fixup! :bug: fix: crash", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &GitmojiTypeRule{}
+			ctx := Context{Type: tt.commitType}
+			if err := rule.ValidateWithContext(tt.text, ctx); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWithContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := rule.FixWithContext(tt.text, ctx); got != tt.want {
+				t.Errorf("FixWithContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	err := (&GitmojiTypeRule{}).ValidateWithContext("🐛 feat: add login", Context{Type: "feat"})
+	want := "gitmoji 🐛 does not fit type feat, use ✨"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateWithContext() error = %v, want %v", err, want)
+	}
+}
+
+func TestGitmojiFormRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		unicode      string
+		shortcode    string
+		unicodeErr   bool
+		shortcodeErr bool
+	}{
+		{name: "emoji", text: "✨ feat: add login", unicode: "✨ feat: add login", shortcode: ":sparkles: feat: add login", shortcodeErr: true},
+		{name: "shortcode", text: ":bug: fix: crash", unicode: "🐛 fix: crash", shortcode: ":bug: fix: crash", unicodeErr: true},
+		{name: "after fixup prefix", text: "fixup! ♻️ refactor: split", unicode: "fixup! ♻️ refactor: split", shortcode: "fixup! :recycle: refactor: split", shortcodeErr: true},
+		{name: "no gitmoji", text: "feat: add login", unicode: "feat: add login", shortcode: "feat: add login"},
+		{name: "unknown shortcode", text: ":unicorn_face: add", unicode: ":unicorn_face: add", shortcode: ":unicorn_face: add", unicodeErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unicodeRule, shortcodeRule := &GitmojiUnicodeRule{}, &GitmojiShortcodeRule{}
+			if err := unicodeRule.Validate(tt.text); (err != nil) != tt.unicodeErr {
+				t.Errorf("GitmojiUnicodeRule.Validate() error = %v, wantErr %v", err, tt.unicodeErr)
+			}
+			if err := shortcodeRule.Validate(tt.text); (err != nil) != tt.shortcodeErr {
+				t.Errorf("GitmojiShortcodeRule.Validate() error = %v, wantErr %v", err, tt.shortcodeErr)
+			}
+			if got := unicodeRule.Fix(tt.text); got != tt.unicode {
+				t.Errorf("GitmojiUnicodeRule.Fix() = %q, want %q", got, tt.unicode)
+			}
+			if got := shortcodeRule.Fix(tt.text); got != tt.shortcode {
+				t.Errorf("GitmojiShortcodeRule.Fix() = %q, want %q", got, tt.shortcode)
+			}
+		})
+	}
+}
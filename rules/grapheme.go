@@ -0,0 +1,204 @@
+package rules
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemeBreak is a Grapheme_Cluster_Break property value from UAX #29.
+type graphemeBreak int
+
+const (
+	gbOther graphemeBreak = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+)
+
+// prependRanges lists the Prepend characters, mostly prefixed concatenation
+// marks such as the Arabic number sign.
+var prependRanges = [][2]rune{
+	{0x0600, 0x0605}, {0x06DD, 0x06DD}, {0x070F, 0x070F}, {0x0890, 0x0891},
+	{0x08E2, 0x08E2}, {0x0D4E, 0x0D4E}, {0x110BD, 0x110BD}, {0x110CD, 0x110CD},
+	{0x111C2, 0x111C3}, {0x1193F, 0x1193F}, {0x11941, 0x11941}, {0x11A3A, 0x11A3A},
+	{0x11A84, 0x11A89}, {0x11D46, 0x11D46},
+}
+
+// extendRanges lists Grapheme_Extend characters that are not nonspacing or
+// enclosing marks: ZWNJ, halfwidth katakana voicing marks, emoji skin-tone
+// modifiers and emoji tag characters.
+var extendRanges = [][2]rune{
+	{0x200C, 0x200C}, {0xFF9E, 0xFF9F}, {0x1F3FB, 0x1F3FF}, {0xE0020, 0xE007F},
+}
+
+// pictographicRanges approximates the Extended_Pictographic property, which
+// lets emoji ZWJ sequences such as family emoji form a single cluster.
+var pictographicRanges = [][2]rune{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x231A, 0x231B}, {0x2328, 0x2328}, {0x2388, 0x2388}, {0x23CF, 0x23CF},
+	{0x23E9, 0x23F3}, {0x23F8, 0x23FA}, {0x24C2, 0x24C2}, {0x25AA, 0x25AB},
+	{0x25B6, 0x25B6}, {0x25C0, 0x25C0}, {0x25FB, 0x25FE}, {0x2600, 0x27BF},
+	{0x2934, 0x2935}, {0x2B05, 0x2B07}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50},
+	{0x2B55, 0x2B55}, {0x3030, 0x3030}, {0x303D, 0x303D}, {0x3297, 0x3297},
+	{0x3299, 0x3299}, {0x1F000, 0x1F0FF}, {0x1F10D, 0x1F10F}, {0x1F12F, 0x1F12F},
+	{0x1F16C, 0x1F171}, {0x1F17E, 0x1F17F}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A},
+	{0x1F1AD, 0x1F1E5}, {0x1F201, 0x1F20F}, {0x1F21A, 0x1F21A}, {0x1F22F, 0x1F22F},
+	{0x1F232, 0x1F23A}, {0x1F23C, 0x1F23F}, {0x1F249, 0x1F3FA}, {0x1F400, 0x1F53D},
+	{0x1F546, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F774, 0x1F77F}, {0x1F7D5, 0x1F7FF},
+	{0x1F80C, 0x1F80F}, {0x1F848, 0x1F84F}, {0x1F85A, 0x1F85F}, {0x1F888, 0x1F88F},
+	{0x1F8AE, 0x1F8FF}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1FAFF},
+	{0x1FC00, 0x1FFFD},
+}
+
+func inRanges(ranges [][2]rune, char rune) bool {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i][1] >= char
+	})
+	return i < len(ranges) && ranges[i][0] <= char
+}
+
+func isPictographic(char rune) bool {
+	return inRanges(pictographicRanges, char)
+}
+
+// IsEmoji reports whether text is a single emoji, such as ✨, 👩‍💻 or a flag,
+// possibly followed by a variation selector.
+func IsEmoji(text string) bool {
+	clusters := SplitGraphemes(text)
+	if len(clusters) != 1 {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(text)
+	return isPictographic(first) || isRegionalIndicator(first)
+}
+
+func isRegionalIndicator(char rune) bool {
+	return char >= 0x1F1E6 && char <= 0x1F1FF
+}
+
+func graphemeBreakOf(char rune) graphemeBreak {
+	switch {
+	case char == '\r':
+		return gbCR
+	case char == '\n':
+		return gbLF
+	case char == 0x200D:
+		return gbZWJ
+	case isRegionalIndicator(char):
+		return gbRegionalIndicator
+	case inRanges(extendRanges, char), unicode.In(char, unicode.Mn, unicode.Me):
+		return gbExtend
+	case inRanges(prependRanges, char):
+		return gbPrepend
+	case unicode.In(char, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gbControl
+	case char == 0x0E33, char == 0x0EB3, unicode.Is(unicode.Mc, char):
+		return gbSpacingMark
+	case char >= 0x1100 && char <= 0x115F, char >= 0xA960 && char <= 0xA97C:
+		return gbL
+	case char >= 0x1160 && char <= 0x11A7, char >= 0xD7B0 && char <= 0xD7C6:
+		return gbV
+	case char >= 0x11A8 && char <= 0x11FF, char >= 0xD7CB && char <= 0xD7FB:
+		return gbT
+	case char >= 0xAC00 && char <= 0xD7A3:
+		if (char-0xAC00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	}
+	return gbOther
+}
+
+// SplitGraphemes splits text into extended grapheme clusters following the
+// UAX #29 boundary rules GB3 to GB13, so that a letter with combining
+// accents, a flag or an emoji ZWJ sequence is a single user-perceived
+// character. The Indic conjunct rule GB9c is not implemented.
+func SplitGraphemes(text string) []string {
+	var clusters []string
+	start := 0
+	prev := gbOther
+	// pictographic is set while the current cluster is an
+	// Extended_Pictographic character followed by Extend characters (GB11).
+	pictographic := false
+	regionalIndicators := 0
+	for i, char := range text {
+		current := graphemeBreakOf(char)
+		if i > 0 && isGraphemeBoundary(prev, current, pictographic, regionalIndicators, char) {
+			clusters = append(clusters, text[start:i])
+			start = i
+			pictographic = false
+			regionalIndicators = 0
+		}
+		switch {
+		case isPictographic(char):
+			pictographic = true
+		case current != gbExtend && current != gbZWJ:
+			pictographic = false
+		}
+		if current == gbRegionalIndicator {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		prev = current
+	}
+	if start < len(text) {
+		clusters = append(clusters, text[start:])
+	}
+	return clusters
+}
+
+func isGraphemeBoundary(prev, current graphemeBreak, pictographic bool, regionalIndicators int, char rune) bool {
+	switch {
+	case prev == gbCR && current == gbLF: // GB3
+		return false
+	case prev == gbCR || prev == gbLF || prev == gbControl: // GB4
+		return true
+	case current == gbCR || current == gbLF || current == gbControl: // GB5
+		return true
+	case prev == gbL && (current == gbL || current == gbV || current == gbLV || current == gbLVT): // GB6
+		return false
+	case (prev == gbLV || prev == gbV) && (current == gbV || current == gbT): // GB7
+		return false
+	case (prev == gbLVT || prev == gbT) && current == gbT: // GB8
+		return false
+	case current == gbExtend || current == gbZWJ: // GB9
+		return false
+	case current == gbSpacingMark: // GB9a
+		return false
+	case prev == gbPrepend: // GB9b
+		return false
+	case prev == gbZWJ && pictographic && isPictographic(char): // GB11
+		return false
+	case prev == gbRegionalIndicator && current == gbRegionalIndicator: // GB12, GB13
+		return regionalIndicators%2 == 0
+	}
+	return true // GB999
+}
+
+// GraphemeCount returns the number of extended grapheme clusters in text.
+func GraphemeCount(text string) int {
+	if len(text) == utf8.RuneCountInString(text) {
+		// ASCII fast path: apart from CR LF every byte is its own cluster.
+		count := 0
+		for i := 0; i < len(text); i++ {
+			if text[i] != '\n' || i == 0 || text[i-1] != '\r' {
+				count++
+			}
+		}
+		return count
+	}
+	return len(SplitGraphemes(text))
+}
@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"ascii", "ab", []string{"a", "b"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"decomposed cyrillic short i", "йй", []string{"й", "й"}},
+		{"combining accents", "é̂x", []string{"é̂", "x"}},
+		{"skin tone modifier", "👍🏽!", []string{"👍🏽", "!"}},
+		{"flags", "🇷🇺🇺🇸", []string{"🇷🇺", "🇺🇸"}},
+		{"odd regional indicators", "🇷🇺🇺", []string{"🇷🇺", "🇺"}},
+		{"zwj sequence", "👩‍💻x", []string{"👩‍💻", "x"}},
+		{"zwj without pictograph", "a\u200db", []string{"a\u200d", "b"}},
+		{"hangul jamo", "각", []string{"각"}},
+		{"hangul syllables", "한국", []string{"한", "국"}},
+		{"devanagari spacing mark", "कि", []string{"कि"}},
+		{"emoji variation selector", "❤️", []string{"❤️"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitGraphemes(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("SplitGraphemes(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEmoji(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"✨", true},
+		{"🐛", true},
+		{"❤️", true},
+		{"👩‍💻", true},
+		{"🇷🇺", true},
+		{"", false},
+		{"a", false},
+		{"✨✨", false},
+		{":sparkles:", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := IsEmoji(tt.text); got != tt.want {
+				t.Errorf("IsEmoji(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLengthUnit(t *testing.T) {
+	text := "\u0438\u0306🇷🇺👍🏽 漢"
+	tests := []struct {
+		unit string
+		want int
+	}{
+		{"bytes", 24},
+		{"runes", 8},
+		{"graphemes", 5},
+		{"columns", 8},
+		{"Graphemes", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			unit, err := ParseLengthUnit(tt.unit)
+			if err != nil {
+				t.Fatalf("ParseLengthUnit() error = %v", err)
+			}
+			if got := unit.Length(text); got != tt.want {
+				t.Errorf("Length(%q) = %d, want %d", text, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseLengthUnit("words"); err == nil {
+		t.Error("ParseLengthUnit() error = nil, want error")
+	}
+}
@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// affixRule is a PFX or SFX rule of a Hunspell affix file: strip is removed
+// from and add attached to the matching end of a word.
+type affixRule struct {
+	prefix    bool
+	cross     bool
+	strip     string
+	add       string
+	condition *regexp.Regexp
+}
+
+func (a affixRule) apply(word string) (string, bool) {
+	if !a.condition.MatchString(word) {
+		return "", false
+	}
+	if a.prefix {
+		if !strings.HasPrefix(word, a.strip) {
+			return "", false
+		}
+		return a.add + word[len(a.strip):], true
+	}
+	if !strings.HasSuffix(word, a.strip) {
+		return "", false
+	}
+	return word[:len(word)-len(a.strip)] + a.add, true
+}
+
+// affixHeader is the "PFX flag cross_product count" line starting the rules
+// of a flag.
+type affixHeader struct {
+	line  int
+	kind  string
+	flag  rune
+	count int
+}
+
+// parseAffixes parses the PFX and SFX rules of a Hunspell .aff file, keyed by
+// their single-character flags. Other directives are ignored. Each flag must
+// have as many rules as its header declares.
+func parseAffixes(aff string) (map[rune][]affixRule, error) {
+	affixes := map[rune][]affixRule{}
+	cross := map[rune]bool{}
+	var headers []affixHeader
+	for number, line := range strings.Split(aff, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || (fields[0] != "PFX" && fields[0] != "SFX") {
+			continue
+		}
+		flag := []rune(fields[1])[0]
+		if len(fields) == 4 {
+			// Header: PFX flag cross_product count
+			count, err := strconv.Atoi(fields[3])
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("affix file line %d: invalid rule count %s", number+1, fields[3])
+			}
+			cross[flag] = fields[2] == "Y"
+			headers = append(headers, affixHeader{line: number + 1, kind: fields[0], flag: flag, count: count})
+			continue
+		}
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("affix file line %d: invalid affix rule", number+1)
+		}
+		prefix := fields[0] == "PFX"
+		strip, add, condition := fields[2], fields[3], fields[4]
+		if strip == "0" {
+			strip = ""
+		}
+		// Affixes with continuation classes ("s/X") are added without them.
+		add, _, _ = strings.Cut(add, "/")
+		if add == "0" {
+			add = ""
+		}
+		pattern := "(?:" + condition + ")$"
+		if prefix {
+			pattern = "^(?:" + condition + ")"
+		}
+		if condition == "." {
+			pattern = ""
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("affix file line %d: invalid condition %s", number+1, condition)
+		}
+		affixes[flag] = append(affixes[flag], affixRule{prefix: prefix, cross: cross[flag], strip: strip, add: add, condition: re})
+	}
+	for _, header := range headers {
+		if got := len(affixes[header.flag]); got != header.count {
+			return nil, fmt.Errorf("affix file line %d: %s %c declares %d rules, got %d", header.line, header.kind, header.flag, header.count, got)
+		}
+	}
+	return affixes, nil
+}
+
+// parseDictionary expands the words of a Hunspell .dic file with the affix
+// rules of their flags, including the cross products of prefixes and suffixes
+// that allow them, into a set of correctly spelled words.
+func parseDictionary(dic string, affixes map[rune][]affixRule) map[string]bool {
+	words := map[string]bool{}
+	for i, line := range strings.Split(dic, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[0]); err == nil && i == 0 {
+			// The first line holds the approximate word count.
+			continue
+		}
+		word, flags, _ := strings.Cut(fields[0], "/")
+		words[word] = true
+		var prefixed []string
+		for _, flag := range flags {
+			for _, rule := range affixes[flag] {
+				if form, ok := rule.apply(word); ok {
+					words[form] = true
+					if rule.prefix && rule.cross {
+						prefixed = append(prefixed, form)
+					}
+				}
+			}
+		}
+		for _, form := range prefixed {
+			for _, flag := range flags {
+				for _, rule := range affixes[flag] {
+					if rule.prefix || !rule.cross {
+						continue
+					}
+					if suffixed, ok := rule.apply(form); ok {
+						words[suffixed] = true
+					}
+				}
+			}
+		}
+	}
+	return words
+}
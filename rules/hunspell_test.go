@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseDictionary(t *testing.T) {
+	aff := `SET UTF-8
+
+PFX U Y 1
+PFX U 0 un .
+
+SFX S Y 3
+SFX S y ies [^aeiou]y
+SFX S 0 s [aeiou]y
+SFX S 0 s [^y]
+
+SFX D N 2
+SFX D 0 d e
+SFX D 0 ed [^e]
+`
+	dic := "3\nlock/USD\ncopy/S\nvalidate/D\n"
+
+	affixes, err := parseAffixes(aff)
+	if err != nil {
+		t.Fatalf("parseAffixes() error = %v", err)
+	}
+	words := parseDictionary(dic, affixes)
+
+	want := []string{"lock", "locks", "locked", "unlock", "unlocks", "copy", "copies", "validate", "validated"}
+	for _, word := range want {
+		if !words[word] {
+			t.Errorf("parseDictionary() is missing %q", word)
+		}
+	}
+	// D does not allow cross products, so "unlocked" is not derived.
+	for _, word := range []string{"copys", "validateed", "unlocked", "3"} {
+		if words[word] {
+			t.Errorf("parseDictionary() contains %q", word)
+		}
+	}
+	if got := len(words); got != len(want) {
+		keys := make([]string, 0, len(words))
+		for word := range words {
+			keys = append(keys, word)
+		}
+		slices.Sort(keys)
+		t.Errorf("parseDictionary() = %v, want %d words", keys, len(want))
+	}
+}
+
+func TestParseAffixesInvalidCondition(t *testing.T) {
+	if _, err := parseAffixes("SFX S Y 1\nSFX S 0 s [abc\n"); err == nil {
+		t.Error("parseAffixes() expected error for an invalid condition")
+	}
+}
+
+func TestParseAffixesRuleCount(t *testing.T) {
+	tests := []struct {
+		name string
+		aff  string
+		want string
+	}{
+		{"too few rules", "SFX S Y 2\nSFX S 0 s .\n", "affix file line 1: SFX S declares 2 rules, got 1"},
+		{"too many rules", "PFX U Y 1\nPFX U 0 un .\nPFX U 0 re .\n", "affix file line 1: PFX U declares 1 rules, got 2"},
+		{"invalid count", "SFX S Y many\nSFX S 0 s .\n", "affix file line 1: invalid rule count many"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseAffixes(tt.aff); err == nil || err.Error() != tt.want {
+				t.Errorf("parseAffixes() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbeddedDictionaries(t *testing.T) {
+	tests := []struct {
+		language string
+		words    []string
+	}{
+		{"en", []string{"add", "adds", "added", "adding", "branches", "unlock", "rebuild", "validation"}},
+		{"ru", []string{"исправить", "исправил", "ошибку", "ошибок", "функции", "сообщением", "зависимостей", "новую"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			words := embeddedDictionaries[tt.language]()
+			for _, word := range tt.words {
+				if !words[word] {
+					t.Errorf("%s dictionary is missing %q", tt.language, word)
+				}
+			}
+		})
+	}
+}
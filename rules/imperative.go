@@ -0,0 +1,186 @@
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	//go:embed data/verbs_en.txt
+	englishVerbList string
+	//go:embed data/verbs_ru.txt
+	russianVerbList string
+
+	englishVerbs = parseVerbList(englishVerbList, englishForms)
+	russianVerbs = parseVerbList(russianVerbList, russianForms)
+)
+
+// verbList holds the expected verb forms of a language and the forms commit
+// descriptions use by mistake, mapped back to the expected form.
+type verbList struct {
+	bases map[string]bool
+	forms map[string]string
+}
+
+// parseVerbList parses a verb list in which each line holds an expected form,
+// optionally followed by irregular mistaken forms. inflect derives the regular
+// mistaken forms.
+func parseVerbList(list string, inflect func(base string) []string) verbList {
+	verbs := verbList{bases: map[string]bool{}, forms: map[string]string{}}
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(strings.ToLower(line))
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		base := fields[0]
+		verbs.bases[base] = true
+		for _, form := range append(inflect(base), fields[1:]...) {
+			if _, ok := verbs.forms[form]; !ok {
+				verbs.forms[form] = base
+			}
+		}
+	}
+	return verbs
+}
+
+// englishForms derives the third person, past tense and -ing forms of an
+// English verb. Both spellings are produced for verbs that may double their
+// final consonant, since a form that isn't a word never matches anyway.
+func englishForms(base string) []string {
+	runes := []rune(base)
+	n := len(runes)
+	last := runes[n-1]
+	consonantY := last == 'y' && n > 1 && !isEnglishVowel(runes[n-2])
+
+	var forms []string
+	switch {
+	case strings.HasSuffix(base, "s"), strings.HasSuffix(base, "x"), strings.HasSuffix(base, "z"),
+		strings.HasSuffix(base, "ch"), strings.HasSuffix(base, "sh"), strings.HasSuffix(base, "o"):
+		forms = append(forms, base+"es")
+	case consonantY:
+		forms = append(forms, base[:len(base)-1]+"ies")
+	default:
+		forms = append(forms, base+"s")
+	}
+
+	switch {
+	case last == 'e':
+		forms = append(forms, base+"d")
+	case consonantY:
+		forms = append(forms, base[:len(base)-1]+"ied")
+	default:
+		forms = append(forms, base+"ed")
+	}
+
+	switch {
+	case strings.HasSuffix(base, "ie"):
+		forms = append(forms, base[:len(base)-2]+"ying")
+	case last == 'e' && !strings.HasSuffix(base, "ee"):
+		forms = append(forms, base[:len(base)-1]+"ing")
+	default:
+		forms = append(forms, base+"ing")
+	}
+
+	if n >= 3 && !isEnglishVowel(last) && !strings.ContainsRune("wxy", last) &&
+		isEnglishVowel(runes[n-2]) && !isEnglishVowel(runes[n-3]) {
+		doubled := base + string(last)
+		forms = append(forms, doubled+"ed", doubled+"ing")
+	}
+	return forms
+}
+
+func isEnglishVowel(char rune) bool {
+	return strings.ContainsRune("aeiou", char)
+}
+
+// russianForms derives the past tense forms of a Russian infinitive, such as
+// "исправил" and "исправили" for "исправить".
+func russianForms(base string) []string {
+	stem, ok := strings.CutSuffix(base, "ть")
+	if !ok {
+		return nil
+	}
+	return []string{stem + "л", stem + "ла", stem + "ло", stem + "ли"}
+}
+
+// ImperativeRule requires text to start with a verb in the form commit
+// descriptions use: the imperative in English ("Add", not "Added", "Adds" or
+// "Adding") and the infinitive in Russian ("Исправить", not "Исправил").
+// Languages lists "en" and "ru"; English is checked when it is empty. Only
+// verbs from the embedded lists are recognized.
+type ImperativeRule struct {
+	Languages []string
+}
+
+func (r *ImperativeRule) Validate(text string) error {
+	first, suggestion, mood, err := r.check(text)
+	if err != nil || suggestion == "" {
+		return err
+	}
+	return fmt.Errorf("text must start with a verb in the %s: use %q instead of %q", mood, suggestion, first.text)
+}
+
+// Fix replaces a mistaken first verb with its expected form.
+func (r *ImperativeRule) Fix(text string) string {
+	first, suggestion, _, err := r.check(text)
+	if err != nil || suggestion == "" {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:first.offset]) + suggestion + string(runes[first.offset+utf8.RuneCountInString(first.text):])
+}
+
+// check returns the first word of text and the expected form to replace it
+// with, or "" when the word is fine or not a known verb, along with the name
+// of the expected form.
+func (r *ImperativeRule) check(text string) (word, string, string, error) {
+	languages := r.Languages
+	if len(languages) == 0 {
+		languages = []string{"en"}
+	}
+	verbLists := make([]verbList, 0, len(languages))
+	moods := make([]string, 0, len(languages))
+	for _, language := range languages {
+		switch strings.ToLower(language) {
+		case "en":
+			verbLists = append(verbLists, englishVerbs)
+			moods = append(moods, "imperative mood")
+		case "ru":
+			verbLists = append(verbLists, russianVerbs)
+			moods = append(moods, "infinitive")
+		default:
+			return word{}, "", "", fmt.Errorf("unsupported imperative language: %s", language)
+		}
+	}
+
+	words := splitWords(text)
+	if len(words) == 0 {
+		return word{}, "", "", nil
+	}
+	first := words[0]
+	lower := strings.ReplaceAll(strings.ToLower(first.text), "ё", "е")
+	for _, verbs := range verbLists {
+		if verbs.bases[lower] {
+			return first, "", "", nil
+		}
+	}
+	for i, verbs := range verbLists {
+		if base, ok := verbs.forms[lower]; ok {
+			return first, matchCase(base, first.text), moods[i], nil
+		}
+	}
+	return first, "", "", nil
+}
+
+// matchCase capitalizes word when original starts with an upper case letter.
+func matchCase(word, original string) string {
+	first, _ := utf8.DecodeRuneInString(original)
+	if !unicode.IsUpper(first) {
+		return word
+	}
+	char, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(char)) + word[size:]
+}
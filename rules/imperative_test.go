@@ -0,0 +1,87 @@
+package rules
+
+import "testing"
+
+func TestImperativeRule(t *testing.T) {
+	rule := &ImperativeRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"imperative", "Add login form", false},
+		{"lower case imperative", "fix crash on startup", false},
+		{"past tense", "Added login form", true},
+		{"third person", "Fixes crash on startup", true},
+		{"gerund", "Updating dependencies", true},
+		{"doubled consonant", "Stopped retrying", true},
+		{"irregular past tense", "Wrote tests", true},
+		{"y to ied", "Applied patch", true},
+		{"unknown word", "Dependencies bumped", false},
+		{"russian is ignored", "Исправил ошибку", false},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "ImperativeRule", rule, tests)
+}
+
+func TestImperativeRuleRussian(t *testing.T) {
+	rule := &ImperativeRule{Languages: []string{"ru"}}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"infinitive", "Исправить ошибку", false},
+		{"past tense", "Исправил ошибку", true},
+		{"plural past tense", "Добавили кэш", true},
+		{"irregular past tense with yo", "Перенёс настройки", true},
+		{"english is ignored", "Added login form", false},
+	}
+
+	runRuleTests(t, "ImperativeRule(ru)", rule, tests)
+}
+
+func TestImperativeRuleMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		text      string
+		want      string
+	}{
+		{"english", nil, "Added login form", `text must start with a verb in the imperative mood: use "Add" instead of "Added"`},
+		{"russian", []string{"ru"}, "исправил ошибку", `text must start with a verb in the infinitive: use "исправить" instead of "исправил"`},
+		{"both languages", []string{"en", "ru"}, "Updating docs", `text must start with a verb in the imperative mood: use "Update" instead of "Updating"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&ImperativeRule{Languages: tt.languages}).Validate(tt.text)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImperativeRuleFix(t *testing.T) {
+	rule := &ImperativeRule{Languages: []string{"en", "ru"}}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Fixes crash on startup", "Fix crash on startup"},
+		{"russian", "Добавил кэш", "Добавить кэш"},
+		{"leading punctuation", "`Adding` docs", "`Add` docs"},
+		{"already imperative", "Add docs", "Add docs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Fix(tt.text); got != tt.want {
+				t.Errorf("Fix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
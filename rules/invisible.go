@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// invisibleChar is a character that renders as nothing or as an ordinary
+// space, so that two visually identical strings compare unequal.
+type invisibleChar struct {
+	name string
+	// space is set for characters the fix replaces with an ordinary space
+	// rather than removes.
+	space bool
+}
+
+var invisibleChars = map[rune]invisibleChar{
+	0x00A0: {"NO-BREAK SPACE", true},
+	0x00AD: {"SOFT HYPHEN", false},
+	0x034F: {"COMBINING GRAPHEME JOINER", false},
+	0x061C: {"ARABIC LETTER MARK", false},
+	0x1680: {"OGHAM SPACE MARK", true},
+	0x180E: {"MONGOLIAN VOWEL SEPARATOR", false},
+	0x2000: {"EN QUAD", true},
+	0x2001: {"EM QUAD", true},
+	0x2002: {"EN SPACE", true},
+	0x2003: {"EM SPACE", true},
+	0x2004: {"THREE-PER-EM SPACE", true},
+	0x2005: {"FOUR-PER-EM SPACE", true},
+	0x2006: {"SIX-PER-EM SPACE", true},
+	0x2007: {"FIGURE SPACE", true},
+	0x2008: {"PUNCTUATION SPACE", true},
+	0x2009: {"THIN SPACE", true},
+	0x200A: {"HAIR SPACE", true},
+	0x200B: {"ZERO WIDTH SPACE", false},
+	0x200C: {"ZERO WIDTH NON-JOINER", false},
+	0x200D: {"ZERO WIDTH JOINER", false},
+	0x200E: {"LEFT-TO-RIGHT MARK", false},
+	0x200F: {"RIGHT-TO-LEFT MARK", false},
+	0x202A: {"LEFT-TO-RIGHT EMBEDDING", false},
+	0x202B: {"RIGHT-TO-LEFT EMBEDDING", false},
+	0x202C: {"POP DIRECTIONAL FORMATTING", false},
+	0x202D: {"LEFT-TO-RIGHT OVERRIDE", false},
+	0x202E: {"RIGHT-TO-LEFT OVERRIDE", false},
+	0x202F: {"NARROW NO-BREAK SPACE", true},
+	0x205F: {"MEDIUM MATHEMATICAL SPACE", true},
+	0x2060: {"WORD JOINER", false},
+	0x2061: {"FUNCTION APPLICATION", false},
+	0x2062: {"INVISIBLE TIMES", false},
+	0x2063: {"INVISIBLE SEPARATOR", false},
+	0x2064: {"INVISIBLE PLUS", false},
+	0x2066: {"LEFT-TO-RIGHT ISOLATE", false},
+	0x2067: {"RIGHT-TO-LEFT ISOLATE", false},
+	0x2068: {"FIRST STRONG ISOLATE", false},
+	0x2069: {"POP DIRECTIONAL ISOLATE", false},
+	0xFEFF: {"ZERO WIDTH NO-BREAK SPACE", false},
+}
+
+// invisibleOccurrence is an invisible character at a 1-based line and
+// character position.
+type invisibleOccurrence struct {
+	char   rune
+	line   int
+	column int
+	// offset is the byte offset of char.
+	offset int
+}
+
+// findInvisibleChars returns the invisible characters in text. A zero width
+// joiner inside an emoji sequence such as 👩‍💻 is legitimate and skipped.
+func findInvisibleChars(text string) []invisibleOccurrence {
+	var found []invisibleOccurrence
+	line, column, offset := 1, 0, 0
+	for _, cluster := range SplitGraphemes(text) {
+		emoji := strings.IndexFunc(cluster, isPictographic) >= 0
+		for i, char := range cluster {
+			column++
+			if _, ok := invisibleChars[char]; ok && !(char == 0x200D && emoji) {
+				found = append(found, invisibleOccurrence{char: char, line: line, column: column, offset: offset + i})
+			}
+			if char == '\n' {
+				line, column = line+1, 0
+			}
+		}
+		offset += len(cluster)
+	}
+	return found
+}
+
+// NoInvisibleCharsRule prevents zero-width characters, non-breaking and other
+// unusual spaces, byte order marks and bidirectional control characters, the
+// last of which enable "Trojan Source" attacks.
+type NoInvisibleCharsRule struct{}
+
+func (r *NoInvisibleCharsRule) Validate(text string) error {
+	found := findInvisibleChars(text)
+	if len(found) == 0 {
+		return nil
+	}
+	first := found[0]
+	return fmt.Errorf("text contains invisible character U+%04X %s at line %d, character %d", first.char, invisibleChars[first.char].name, first.line, first.column)
+}
+
+// Fix removes invisible characters and replaces unusual spaces with ordinary
+// spaces.
+func (r *NoInvisibleCharsRule) Fix(text string) string {
+	found := findInvisibleChars(text)
+	for i := len(found) - 1; i >= 0; i-- {
+		occurrence := found[i]
+		replacement := ""
+		if invisibleChars[occurrence.char].space {
+			replacement = " "
+		}
+		text = text[:occurrence.offset] + replacement + text[occurrence.offset+len(string(occurrence.char)):]
+	}
+	return text
+}
@@ -0,0 +1,56 @@
+package rules
+
+import "testing"
+
+func TestNoInvisibleCharsRule(t *testing.T) {
+	rule := &NoInvisibleCharsRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"plain text", "fix parser crash", false},
+		{"cyrillic text", "исправить ошибку", false},
+		{"emoji zwj sequence", "add 👩\u200d💻 emoji", false},
+		{"zero width space", "fix\u200b parser", true},
+		{"no-break space", "fix\u00a0parser", true},
+		{"byte order mark", "\ufefffix parser", true},
+		{"bidi override", "fix \u202eparser", true},
+		{"bidi isolate", "fix \u2066parser\u2069", true},
+		{"soft hyphen", "pars\u00ader", true},
+		{"zwj outside emoji", "fix\u200dparser", true},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "NoInvisibleCharsRule", rule, tests)
+}
+
+func TestNoInvisibleCharsRuleMessage(t *testing.T) {
+	err := (&NoInvisibleCharsRule{}).Validate("fix: parser\n\nline\u200b two")
+	want := "text contains invisible character U+200B ZERO WIDTH SPACE at line 3, character 5"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
+
+func TestNoInvisibleCharsRuleFix(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"zero width space", "fix\u200b parser", "fix parser"},
+		{"no-break space", "fix\u00a0parser", "fix parser"},
+		{"bidi controls", "fix \u2066parser\u2069", "fix parser"},
+		{"byte order mark", "\ufefffix", "fix"},
+		{"emoji zwj sequence kept", "add 👩\u200d💻\u200b", "add 👩\u200d💻"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&NoInvisibleCharsRule{}).Fix(tt.text); got != tt.want {
+				t.Errorf("Fix(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
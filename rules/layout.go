@@ -0,0 +1,196 @@
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	//go:embed data/words_en.txt
+	englishWordList string
+	//go:embed data/words_ru.txt
+	russianWordList string
+
+	englishWords = parseWordList(englishWordList)
+	russianWords = parseWordList(russianWordList)
+)
+
+// parseWordList parses a newline-separated word list, skipping blank lines
+// and '#' comments.
+func parseWordList(list string) map[string]bool {
+	words := map[string]bool{}
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words[strings.ToLower(line)] = true
+	}
+	return words
+}
+
+// qwertyToJcuken maps the keys of a US QWERTY keyboard to the characters the
+// same keys type on the Russian ЙЦУКЕН layout.
+var qwertyToJcuken = map[rune]rune{
+	'q': 'й', 'w': 'ц', 'e': 'у', 'r': 'к', 't': 'е', 'y': 'н', 'u': 'г',
+	'i': 'ш', 'o': 'щ', 'p': 'з', '[': 'х', ']': 'ъ', 'a': 'ф', 's': 'ы',
+	'd': 'в', 'f': 'а', 'g': 'п', 'h': 'р', 'j': 'о', 'k': 'л', 'l': 'д',
+	';': 'ж', '\'': 'э', 'z': 'я', 'x': 'ч', 'c': 'с', 'v': 'м', 'b': 'и',
+	'n': 'т', 'm': 'ь', ',': 'б', '.': 'ю', '`': 'ё',
+	'Q': 'Й', 'W': 'Ц', 'E': 'У', 'R': 'К', 'T': 'Е', 'Y': 'Н', 'U': 'Г',
+	'I': 'Ш', 'O': 'Щ', 'P': 'З', '{': 'Х', '}': 'Ъ', 'A': 'Ф', 'S': 'Ы',
+	'D': 'В', 'F': 'А', 'G': 'П', 'H': 'Р', 'J': 'О', 'K': 'Л', 'L': 'Д',
+	':': 'Ж', '"': 'Э', 'Z': 'Я', 'X': 'Ч', 'C': 'С', 'V': 'М', 'B': 'И',
+	'N': 'Т', 'M': 'Ь', '<': 'Б', '>': 'Ю', '~': 'Ё',
+}
+
+var jcukenToQwerty = map[rune]rune{}
+
+func init() {
+	for latin, cyrillic := range qwertyToJcuken {
+		jcukenToQwerty[cyrillic] = latin
+	}
+}
+
+// layoutToken is a word typed in one keyboard layout, at a byte offset.
+type layoutToken struct {
+	text   string
+	start  int
+	end    int
+	mapped string
+}
+
+// layoutMistake is a run of words that reads as text in the other layout.
+type layoutMistake struct {
+	start    int
+	end      int
+	intended string
+}
+
+// NoWrongLayoutRule detects text typed with the wrong keyboard layout
+// active, such as "ашч ыщьу игп" for "fix some bug" typed on ЙЦУКЕН or
+// "bcghfdbnm" for "исправить" typed on QWERTY.
+type NoWrongLayoutRule struct{}
+
+func (r *NoWrongLayoutRule) Validate(text string) error {
+	mistakes := findLayoutMistakes(text)
+	if len(mistakes) == 0 {
+		return nil
+	}
+	m := mistakes[0]
+	return fmt.Errorf("text %q looks typed in the wrong keyboard layout, did you mean %q?", text[m.start:m.end], m.intended)
+}
+
+// Fix replaces text typed in the wrong keyboard layout with the intended text.
+func (r *NoWrongLayoutRule) Fix(text string) string {
+	mistakes := findLayoutMistakes(text)
+	for i := len(mistakes) - 1; i >= 0; i-- {
+		m := mistakes[i]
+		text = text[:m.start] + m.intended + text[m.end:]
+	}
+	return text
+}
+
+// findLayoutMistakes groups words of the same script into runs and reports
+// the runs in which more words become known words when retyped in the other
+// layout than are known as written. Retyped words shorter than three letters
+// are not counted, since almost any short key sequence is a word.
+func findLayoutMistakes(text string) []layoutMistake {
+	var mistakes []layoutMistake
+	tokens := layoutTokens(text)
+	for i := 0; i < len(tokens); {
+		j := i + 1
+		for j < len(tokens) && sameLayoutScript(tokens[i].text, tokens[j].text) && isSpaceOnly(text[tokens[j-1].end:tokens[j].start]) {
+			j++
+		}
+		known, retypedKnown := 0, 0
+		for _, token := range tokens[i:j] {
+			if isKnownWord(token.text) {
+				known++
+			}
+			if utf8.RuneCountInString(token.mapped) >= 3 && isKnownWord(token.mapped) {
+				retypedKnown++
+			}
+		}
+		if retypedKnown > known {
+			var intended strings.Builder
+			for k, token := range tokens[i:j] {
+				if k > 0 {
+					intended.WriteString(text[tokens[i+k-1].end:token.start])
+				}
+				intended.WriteString(token.mapped)
+			}
+			mistakes = append(mistakes, layoutMistake{start: tokens[i].start, end: tokens[j-1].end, intended: intended.String()})
+		}
+		i = j
+	}
+	return mistakes
+}
+
+// layoutTokens splits text into Latin and Cyrillic words. Punctuation keys
+// that type Russian letters, such as ',' for 'б', are part of a Latin word
+// when they are followed by a letter.
+func layoutTokens(text string) []layoutToken {
+	var tokens []layoutToken
+	runes := []rune(text)
+	offset := 0
+	for i := 0; i < len(runes); {
+		char := runes[i]
+		size := utf8.RuneLen(char)
+		switch {
+		case unicode.Is(unicode.Cyrillic, char):
+			start, mapped := offset, []rune{}
+			for i < len(runes) && unicode.Is(unicode.Cyrillic, runes[i]) {
+				if latin, ok := jcukenToQwerty[runes[i]]; ok {
+					mapped = append(mapped, latin)
+				} else {
+					mapped = append(mapped, runes[i])
+				}
+				offset += utf8.RuneLen(runes[i])
+				i++
+			}
+			tokens = append(tokens, layoutToken{text: text[start:offset], start: start, end: offset, mapped: string(mapped)})
+		case isASCIILetter(char):
+			start, mapped := offset, []rune{}
+			for i < len(runes) && (isASCIILetter(runes[i]) || isLetterKey(runes, i)) {
+				mapped = append(mapped, qwertyToJcuken[runes[i]])
+				offset += utf8.RuneLen(runes[i])
+				i++
+			}
+			tokens = append(tokens, layoutToken{text: text[start:offset], start: start, end: offset, mapped: string(mapped)})
+		default:
+			offset += size
+			i++
+		}
+	}
+	return tokens
+}
+
+// isLetterKey reports whether runes[i] is a punctuation key that types a
+// Russian letter and continues a Latin word.
+func isLetterKey(runes []rune, i int) bool {
+	_, ok := qwertyToJcuken[runes[i]]
+	return ok && !isASCIILetter(runes[i]) && i+1 < len(runes) && isASCIILetter(runes[i+1])
+}
+
+func isASCIILetter(char rune) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+func sameLayoutScript(a, b string) bool {
+	first, _ := utf8.DecodeRuneInString(a)
+	second, _ := utf8.DecodeRuneInString(b)
+	return unicode.Is(unicode.Cyrillic, first) == unicode.Is(unicode.Cyrillic, second)
+}
+
+func isSpaceOnly(text string) bool {
+	return strings.TrimSpace(text) == ""
+}
+
+func isKnownWord(word string) bool {
+	word = strings.ToLower(word)
+	return englishWords[word] || russianWords[word]
+}
@@ -0,0 +1,52 @@
+package rules
+
+import "testing"
+
+func TestNoWrongLayoutRule(t *testing.T) {
+	rule := &NoWrongLayoutRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"english", "fix some bug", false},
+		{"russian", "исправить ошибку", false},
+		{"english typed on jcuken", "ашч ыщьу игп", true},
+		{"russian typed on qwerty", "bcghfdbnm jib,re", true},
+		{"mixed correct text", "fix ошибку in parser", false},
+		{"short words only", "b", false},
+		{"unknown words", "qwxz", false},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "NoWrongLayoutRule", rule, tests)
+}
+
+func TestNoWrongLayoutRuleMessage(t *testing.T) {
+	err := (&NoWrongLayoutRule{}).Validate("Ашч ыщьу игп")
+	want := `text "Ашч ыщьу игп" looks typed in the wrong keyboard layout, did you mean "Fix some bug"?`
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
+
+func TestNoWrongLayoutRuleFix(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english typed on jcuken", "ашч ыщьу игп", "fix some bug"},
+		{"russian typed on qwerty", "bcghfdbnm jib,re", "исправить ошибку"},
+		{"only the mistyped run", "parser: ашч ыщьу игп", "parser: fix some bug"},
+		{"correct text", "fix some bug", "fix some bug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&NoWrongLayoutRule{}).Fix(tt.text); got != tt.want {
+				t.Errorf("Fix(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// LengthUnit selects how text length is measured by length limits.
+type LengthUnit int
+
+const (
+	// Runes counts Unicode code points.
+	Runes LengthUnit = iota
+	// Bytes counts UTF-8 bytes.
+	Bytes
+	// Graphemes counts extended grapheme clusters (user-perceived characters).
+	Graphemes
+	// Columns counts terminal display columns.
+	Columns
+)
+
+// ParseLengthUnit returns the LengthUnit with the given name: bytes, runes,
+// graphemes or columns.
+func ParseLengthUnit(name string) (LengthUnit, error) {
+	switch strings.ToLower(name) {
+	case "runes":
+		return Runes, nil
+	case "bytes":
+		return Bytes, nil
+	case "graphemes":
+		return Graphemes, nil
+	case "columns":
+		return Columns, nil
+	default:
+		return Runes, fmt.Errorf("unknown length unit: %s", name)
+	}
+}
+
+// Length returns the length of text in the unit.
+func (u LengthUnit) Length(text string) int {
+	switch u {
+	case Bytes:
+		return len(text)
+	case Graphemes:
+		return GraphemeCount(text)
+	case Columns:
+		return DisplayWidth(text)
+	default:
+		return utf8.RuneCountInString(text)
+	}
+}
+
+// Noun returns the plural noun used for the unit in error messages.
+func (u LengthUnit) Noun() string {
+	switch u {
+	case Bytes:
+		return "bytes"
+	case Columns:
+		return "columns"
+	default:
+		return "characters"
+	}
+}
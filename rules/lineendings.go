@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NoCRLFRule prevents carriage returns, such as the CRLF line endings written
+// by editors configured for Windows.
+type NoCRLFRule struct{}
+
+func (r *NoCRLFRule) Validate(text string) error {
+	index := strings.IndexByte(text, '\r')
+	if index < 0 {
+		return nil
+	}
+	line := strings.Count(text[:index], "\n") + 1
+	return fmt.Errorf("text contains a carriage return (CRLF line ending) at line %d", line)
+}
+
+// Fix converts CRLF line endings to LF and removes other carriage returns.
+func (r *NoCRLFRule) Fix(text string) string {
+	return strings.ReplaceAll(text, "\r", "")
+}
+
+// NoBOMRule prevents a leading UTF-8 byte order mark.
+type NoBOMRule struct{}
+
+func (r *NoBOMRule) Validate(text string) error {
+	if strings.HasPrefix(text, "\ufeff") {
+		return fmt.Errorf("text starts with a UTF-8 byte order mark")
+	}
+	return nil
+}
+
+// Fix removes a leading UTF-8 byte order mark.
+func (r *NoBOMRule) Fix(text string) string {
+	return strings.TrimPrefix(text, "\ufeff")
+}
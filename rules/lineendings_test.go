@@ -0,0 +1,47 @@
+package rules
+
+import "testing"
+
+func TestNoCRLFRule(t *testing.T) {
+	rule := &NoCRLFRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"lf line endings", "feat: add x\n\nbody\n", false},
+		{"crlf line endings", "feat: add x\r\n\r\nbody\r\n", true},
+		{"trailing carriage return", "feat: add x\r", true},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "NoCRLFRule", rule, tests)
+
+	err := rule.Validate("feat: add x\n\nbody\r\n")
+	want := "text contains a carriage return (CRLF line ending) at line 3"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+	if got := rule.Fix("feat: add x\r\n\r\nbody\r\n"); got != "feat: add x\n\nbody\n" {
+		t.Errorf("Fix() = %q", got)
+	}
+}
+
+func TestNoBOMRule(t *testing.T) {
+	rule := &NoBOMRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"no bom", "feat: add x", false},
+		{"leading bom", "\ufefffeat: add x", true},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "NoBOMRule", rule, tests)
+
+	if got := rule.Fix("\ufefffeat: add x"); got != "feat: add x" {
+		t.Errorf("Fix() = %q", got)
+	}
+}
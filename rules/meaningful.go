@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// textWords splits text into runs of letters, digits and combining marks.
+func textWords(text string) []string {
+	return strings.FieldsFunc(text, func(char rune) bool { return !isWordChar(char) })
+}
+
+// MinWordsRule requires text to contain at least Min words. The trailers of
+// a body are not counted.
+type MinWordsRule struct {
+	Min int
+}
+
+func (r *MinWordsRule) Validate(text string) error {
+	return r.ValidateWithContext(text, Context{})
+}
+
+func (r *MinWordsRule) ValidateWithContext(text string, ctx Context) error {
+	if count := len(textWords(bodyText(text, ctx))); count < r.Min {
+		return fmt.Errorf("text must contain at least %d words, got %d", r.Min, count)
+	}
+	return nil
+}
+
+// parseMinWords parses the single positive argument of minWords(n).
+func parseMinWords(args []string) (Rule, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("rule minWords requires one argument, the minimum number of words")
+	}
+	min, err := strconv.Atoi(args[0])
+	if err != nil || min < 1 {
+		return nil, fmt.Errorf("rule minWords requires a positive number of words, got %s", args[0])
+	}
+	return &MinWordsRule{Min: min}, nil
+}
+
+// keyboardRows are the letter rows of the QWERTY and ЙЦУКЕН layouts. Runs of
+// adjacent keys, such as "asdf" or "йцукен", are keyboard mashes.
+var keyboardRows = []string{
+	"qwertyuiop", "asdfghjkl", "zxcvbnm",
+	"йцукенгшщзхъ", "фывапролджэ", "ячсмитьбю",
+}
+
+// minKeyboardRun is the shortest run of adjacent keys that counts as a
+// keyboard mash.
+const minKeyboardRun = 4
+
+// MeaningfulRule rejects text that carries no information: a single word,
+// no letters at all, a word repeating one character such as "aaaa", or a run
+// of adjacent keyboard keys such as "qwerty" or "йцукен".
+type MeaningfulRule struct{}
+
+func (r *MeaningfulRule) Validate(text string) error {
+	if strings.IndexFunc(text, unicode.IsLetter) < 0 {
+		return fmt.Errorf("text must contain words")
+	}
+	words := textWords(text)
+	for _, w := range words {
+		if isRepeatedChar(w) {
+			return fmt.Errorf("text must be meaningful: %q repeats one character", w)
+		}
+		if isKeyboardMash(w) {
+			return fmt.Errorf("text must be meaningful: %q is a run of adjacent keyboard keys", w)
+		}
+	}
+	if len(words) < 2 {
+		return fmt.Errorf("text must contain more than one word")
+	}
+	return nil
+}
+
+// isRepeatedChar reports whether w repeats one letter at least four times,
+// such as "aaaa", and is not a known word. Shorter runs such as "www" and
+// runs of one digit, such as port 8000 or error 777, are meaningful.
+func isRepeatedChar(w string) bool {
+	runes := []rune(strings.ToLower(w))
+	if len(runes) < 4 || !unicode.IsLetter(runes[0]) || isKnownWord(w) {
+		return false
+	}
+	for _, char := range runes[1:] {
+		if char != runes[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// isKeyboardMash reports whether w, read forwards or backwards, is a run of
+// adjacent keys in a keyboard row and not a known word.
+func isKeyboardMash(w string) bool {
+	lower := strings.ToLower(w)
+	runes := []rune(lower)
+	if len(runes) < minKeyboardRun || isKnownWord(lower) {
+		return false
+	}
+	reversed := make([]rune, len(runes))
+	for i, char := range runes {
+		reversed[len(runes)-1-i] = char
+	}
+	for _, row := range keyboardRows {
+		if strings.Contains(row, lower) || strings.Contains(row, string(reversed)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package rules
+
+import "testing"
+
+func TestMinWordsRule(t *testing.T) {
+	rule := &MinWordsRule{Min: 3}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"enough words", "Add login form", false},
+		{"cyrillic words", "Добавить форму входа", false},
+		{"too few words", "Add login", true},
+		{"punctuation is not a word", "x - y", true},
+		{"empty string", "", true},
+	}
+
+	runRuleTests(t, "MinWordsRule", rule, tests)
+
+	err := rule.Validate("x")
+	want := "text must contain at least 3 words, got 1"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
+
+func TestMeaningfulRule(t *testing.T) {
+	rule := &MeaningfulRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"meaningful english", "Add login form", false},
+		{"meaningful russian", "Исправить ошибку входа", false},
+		{"single word", "x", true},
+		{"single long word", "refactoring", true},
+		{"repeated character", "aaaa", true},
+		{"repeated character among words", "fix aaaa bug", true},
+		{"no letters", "...", true},
+		{"qwerty mash", "qwerty stuff", true},
+		{"asdf mash", "asdf", true},
+		{"reversed mash", "fdsa things", true},
+		{"jcuken mash", "йцукен тест", true},
+		{"short key run", "add wer check", false},
+		{"repeated digits", "Reserve port 7777", false},
+		{"repeated digit code", "Handle error 777", false},
+		{"three repeated letters", "Redirect www to apex", false},
+		{"zero padding", "Pad ids with 000", false},
+		{"empty string", "", true},
+	}
+
+	runRuleTests(t, "MeaningfulRule", rule, tests)
+}
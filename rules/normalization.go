@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ParseNormalForm returns the Unicode normalization form with the given name:
+// NFC, NFD, NFKC or NFKD.
+func ParseNormalForm(name string) (norm.Form, error) {
+	switch strings.ToUpper(name) {
+	case "NFC":
+		return norm.NFC, nil
+	case "NFD":
+		return norm.NFD, nil
+	case "NFKC":
+		return norm.NFKC, nil
+	case "NFKD":
+		return norm.NFKD, nil
+	default:
+		return norm.NFC, fmt.Errorf("unknown normalization form: %s", name)
+	}
+}
+
+// NormalFormRule requires text to already be in a Unicode normalization form.
+type NormalFormRule struct {
+	Form norm.Form
+}
+
+func (r *NormalFormRule) Validate(text string) error {
+	if r.Form.IsNormalString(text) {
+		return nil
+	}
+	offset := r.Form.QuickSpanString(text)
+	// QuickSpan stops at the last boundary before the offending character.
+	return fmt.Errorf("text is not in %s normalization form at character %d", formName(r.Form), utf8.RuneCountInString(text[:offset])+1)
+}
+
+// Fix converts text to the normalization form.
+func (r *NormalFormRule) Fix(text string) string {
+	return r.Form.String(text)
+}
+
+func formName(form norm.Form) string {
+	switch form {
+	case norm.NFD:
+		return "NFD"
+	case norm.NFKC:
+		return "NFKC"
+	case norm.NFKD:
+		return "NFKD"
+	default:
+		return "NFC"
+	}
+}
@@ -0,0 +1,52 @@
+package rules
+
+import (
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestNormalFormRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		form    norm.Form
+		text    string
+		wantErr bool
+	}{
+		{"ascii in nfc", norm.NFC, "fix bug", false},
+		{"composed in nfc", norm.NFC, "\u0439", false},
+		{"decomposed in nfc", norm.NFC, "\u0438\u0306", true},
+		{"decomposed in nfd", norm.NFD, "\u0438\u0306", false},
+		{"composed in nfd", norm.NFD, "\u0439", true},
+		{"compatibility character in nfc", norm.NFC, "\ufb01x", false},
+		{"compatibility character in nfkc", norm.NFKC, "\ufb01x", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&NormalFormRule{Form: tt.form}).Validate(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalFormRuleReportsPosition(t *testing.T) {
+	err := (&NormalFormRule{Form: norm.NFC}).Validate("fix \u0438\u0306")
+	want := "text is not in NFC normalization form at character 5"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
+
+func TestParseNormalForm(t *testing.T) {
+	for _, name := range []string{"NFC", "nfd", "Nfkc", "NFKD"} {
+		if _, err := ParseNormalForm(name); err != nil {
+			t.Errorf("ParseNormalForm(%q) error = %v", name, err)
+		}
+	}
+	if _, err := ParseNormalForm("NFX"); err == nil {
+		t.Error("ParseNormalForm() error = nil, want error")
+	}
+}
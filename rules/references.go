@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// jiraPattern matches JIRA keys such as "ABC-123", whose project key has at
+// least two letters.
+var jiraPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*[A-Z][A-Z0-9]*-[0-9]+\b`)
+
+// referencePatterns are the built-in issue reference formats. The first
+// capture group, when present, is the reference.
+var referencePatterns = map[string]*regexp.Regexp{
+	"jira":   jiraPattern,
+	"github": regexp.MustCompile(`(?:^|[^\w/.#-])(#[0-9]+)\b`),
+	"gitlab": regexp.MustCompile(`\b[\w.-]+(?:/[\w.-]+)+#[0-9]+\b`),
+}
+
+// standardNames are the prefixes of encodings, hash functions and standards
+// such as UTF-8, SHA-256 or ISO-8859 that look like JIRA keys.
+var standardNames = []string{"AES", "CP", "CVE", "ECMA", "HTTP", "IEC", "ISO", "RFC", "SHA", "TLS", "UCS", "UTF"}
+
+// ParseReferencePattern returns the built-in reference pattern named "jira"
+// ("ABC-123"), "github" ("#123") or "gitlab" ("group/proj#12"), or compiles
+// pattern as a regular expression.
+func ParseReferencePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := referencePatterns[strings.ToLower(pattern)]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference pattern %s: %v", pattern, err)
+	}
+	return re, nil
+}
+
+// FindReferences returns the references in text matching pattern. The jira
+// pattern skips names such as UTF-8 and SHA-256.
+func FindReferences(text string, pattern *regexp.Regexp) []string {
+	var references []string
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		reference := match[0]
+		if len(match) > 1 {
+			reference = match[1]
+		}
+		if pattern == jiraPattern && isStandardName(reference) {
+			continue
+		}
+		references = append(references, reference)
+	}
+	return references
+}
+
+func isStandardName(reference string) bool {
+	key, _, _ := strings.Cut(reference, "-")
+	return slices.Contains(standardNames, key)
+}
+
+// BranchTicket returns the first reference matching pattern in a branch name,
+// e.g. "TGK-1827" in "feature/TGK-1827-login", or "" when there is none.
+func BranchTicket(branch string, pattern *regexp.Regexp) string {
+	if references := FindReferences(branch, pattern); len(references) > 0 {
+		return references[0]
+	}
+	return ""
+}
+
+// BranchTicketRule requires text to reference the ticket of the current
+// branch, found with Pattern, a reference pattern name or regular expression
+// that defaults to "jira". Text is not checked when the branch is unknown or
+// has no ticket.
+type BranchTicketRule struct {
+	Pattern string
+}
+
+func (r *BranchTicketRule) Validate(text string) error {
+	return r.ValidateWithContext(text, Context{})
+}
+
+func (r *BranchTicketRule) ValidateWithContext(text string, ctx Context) error {
+	name := r.Pattern
+	if name == "" {
+		name = "jira"
+	}
+	pattern, err := ParseReferencePattern(name)
+	if err != nil {
+		return err
+	}
+	ticket := BranchTicket(ctx.Branch, pattern)
+	if ticket == "" || containsWord(strings.ToLower(text), strings.ToLower(ticket)) {
+		return nil
+	}
+	if references := FindReferences(text, pattern); len(references) > 0 {
+		return fmt.Errorf("text references %s, but branch %s is for %s", references[0], ctx.Branch, ticket)
+	}
+	return fmt.Errorf("text must reference ticket %s from branch %s", ticket, ctx.Branch)
+}
@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindReferences(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    []string
+	}{
+		{"jira", "feat(TGK-1827): add login, see ABC-1", []string{"TGK-1827", "ABC-1"}},
+		{"jira", "add UTF-8 support", nil},
+		{"jira", "switch from SHA-256 to ISO-8859-1 names", nil},
+		{"jira", "fix CVE-2024-3094 in X-11", nil},
+		{"jira", "rename A1-2 to AB1-2", []string{"AB1-2"}},
+		{"github", "fix crash (#42), closes #7", []string{"#42", "#7"}},
+		{"github", "see group/proj#12", nil},
+		{"gitlab", "see group/proj#12", []string{"group/proj#12"}},
+		{`T(\d+)`, "ticket T12", []string{"12"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			pattern, err := ParseReferencePattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("ParseReferencePattern() error = %v", err)
+			}
+			if got := FindReferences(tt.text, pattern); !slices.Equal(got, tt.want) {
+				t.Errorf("FindReferences(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchTicketRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		branch  string
+		text    string
+		want    string
+	}{
+		{"matching ticket", "", "feature/TGK-1827-login", "feat(TGK-1827): add login", ""},
+		{"matching ticket in lower case", "", "feature/TGK-1827-login", "feat(tgk-1827): add login", ""},
+		{"missing ticket", "", "feature/TGK-1827-login", "feat: add login", "text must reference ticket TGK-1827 from branch feature/TGK-1827-login"},
+		{"other ticket", "", "feature/TGK-1827-login", "feat(TGK-1828): add login", "text references TGK-1828, but branch feature/TGK-1827-login is for TGK-1827"},
+		{"longer ticket number", "", "feature/TGK-1827-login", "feat(TGK-18270): add login", "text references TGK-18270, but branch feature/TGK-1827-login is for TGK-1827"},
+		{"branch without ticket", "", "main", "feat: add login", ""},
+		{"unknown branch", "", "", "feat: add login", ""},
+		{"custom pattern", `issue-(\d+)`, "fix/issue-42", "fix: crash\n\nCloses #42", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&BranchTicketRule{Pattern: tt.pattern}).ValidateWithContext(tt.text, Context{Branch: tt.branch})
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidateWithContext() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("ValidateWithContext() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchTicketRulePatternWithComma(t *testing.T) {
+	rule, err := RuleFactory(`branchTicket([A-Z]{2,5}-\d+)`)
+	if err != nil {
+		t.Fatalf("RuleFactory() error = %v", err)
+	}
+	err = rule.(ContextRule).ValidateWithContext("feat: add login", Context{Branch: "feature/AB-12-login"})
+	want := "text must reference ticket AB-12 from branch feature/AB-12-login"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateWithContext() error = %v, want %v", err, want)
+	}
+}
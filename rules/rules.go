@@ -3,6 +3,7 @@ package rules
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 )
@@ -18,9 +19,51 @@ type Rule interface {
 	Validate(text string) error
 }
 
-// RuleFactory creates a Rule based on the rule name
+// Context describes the commit message that validated text belongs to.
+type Context struct {
+	// Part is the part of the message being checked: "type", "scope",
+	// "description", "body" or "message".
+	Part  string
+	Type  string
+	Scope string
+	// Branch is the branch the commit is made on, or "" when unknown.
+	Branch string
+	// Author is the commit author as "Name <email>", or "" when unknown.
+	Author string
+}
+
+// ContextRule is implemented by rules whose result also depends on the rest
+// of the commit message. Validate is used when no message is available.
+type ContextRule interface {
+	ValidateWithContext(text string, ctx Context) error
+}
+
+// Fixer is implemented by rules that can rewrite text to satisfy themselves.
+type Fixer interface {
+	Fix(text string) string
+}
+
+// ContextFixer is implemented by rules whose fixes depend on the rest of the
+// commit message.
+type ContextFixer interface {
+	FixWithContext(text string, ctx Context) string
+}
+
+// RuleFactory creates a Rule based on the rule name. Parameterized rules take
+// their arguments in parentheses, e.g. "allowScripts(Latin, Greek)".
 func RuleFactory(ruleName string) (Rule, error) {
-	switch strings.ToLower(ruleName) {
+	name, args, err := parseRuleSpec(ruleName)
+	if err != nil {
+		return nil, err
+	}
+	if rule, ok, err := parameterizedRule(name, args); ok {
+		return rule, err
+	}
+	if len(args) > 0 {
+		return nil, fmt.Errorf("rule %s does not take arguments", name)
+	}
+
+	switch strings.ToLower(name) {
 	case "nocyrillic":
 		return &NoCyrillicRule{}, nil
 	case "nolatin":
@@ -51,29 +94,158 @@ func RuleFactory(ruleName string) (Rule, error) {
 		return &TrailingPeriodRule{}, nil
 	case "notrailingperiod":
 		return &NoTrailingPeriodRule{}, nil
+	case "lowercase":
+		return &LowerCaseRule{}, nil
+	case "uppercase":
+		return &UpperCaseRule{}, nil
+	case "sentencecase":
+		return &SentenceCaseRule{}, nil
+	case "startlowercase":
+		return &StartLowerCaseRule{}, nil
+	case "kebabcase":
+		return &KebabCaseRule{}, nil
+	case "snakecase":
+		return &SnakeCaseRule{}, nil
+	case "camelcase":
+		return &CamelCaseRule{}, nil
+	case "pascalcase":
+		return &PascalCaseRule{}, nil
+	case "blanklineafterheader":
+		return &BlankLineAfterHeaderRule{}, nil
+	case "nomixedscriptwords":
+		return &NoMixedScriptWordsRule{}, nil
+	case "noconfusables":
+		return &NoMixedScriptWordsRule{ConfusablesOnly: true}, nil
+	case "nocrlf":
+		return &NoCRLFRule{}, nil
+	case "nobom":
+		return &NoBOMRule{}, nil
+	case "validutf8":
+		return &ValidUTF8Rule{}, nil
+	case "noinvisiblechars":
+		return &NoInvisibleCharsRule{}, nil
+	case "nowronglayout":
+		return &NoWrongLayoutRule{}, nil
+	case "meaningful":
+		return &MeaningfulRule{}, nil
+	case "notempty":
+		return &NotEmptyRule{}, nil
+	case "empty":
+		return &EmptyRule{}, nil
+	case "revertscommit":
+		return &RevertsCommitRule{}, nil
+	case "nofixup":
+		return &NoFixupRule{}, nil
+	case "requiregitmoji":
+		return &RequireGitmojiRule{}, nil
+	case "nogitmoji":
+		return &NoGitmojiRule{}, nil
+	case "gitmojitype":
+		return &GitmojiTypeRule{}, nil
+	case "gitmojiunicode":
+		return &GitmojiUnicodeRule{}, nil
+	case "gitmojishortcode":
+		return &GitmojiShortcodeRule{}, nil
+	case "validtrailers":
+		return &ValidTrailersRule{}, nil
+	case "noduplicatetrailers":
+		return &NoDuplicateTrailersRule{}, nil
+	case "nomisplacedtrailers":
+		return &NoMisplacedTrailersRule{}, nil
+	case "nfc", "nfd", "nfkc", "nfkd":
+		form, err := ParseNormalForm(name)
+		if err != nil {
+			return nil, err
+		}
+		return &NormalFormRule{Form: form}, nil
 	default:
 		return nil, fmt.Errorf("unknown rule: %s", ruleName)
 	}
 }
 
+// parameterizedRule creates the rules that take arguments. It reports false
+// when name is not a parameterized rule.
+func parameterizedRule(name string, args []string) (Rule, bool, error) {
+	var rule Rule
+	switch strings.ToLower(name) {
+	case "allowscripts":
+		rule = &AllowScriptsRule{Scripts: args}
+	case "onlyscripts":
+		rule = &OnlyScriptsRule{Scripts: args}
+	case "denyscripts":
+		rule = &DenyScriptsRule{Scripts: args}
+	case "imperative":
+		rule = &ImperativeRule{Languages: args}
+	case "denywords":
+		denyWords, err := parseDenyWords(args)
+		return denyWords, true, err
+	case "branchticket":
+		rule = &BranchTicketRule{Pattern: strings.Join(args, "")}
+	case "signedoff":
+		if len(args) > 1 || (len(args) == 1 && !strings.EqualFold(args[0], "any")) {
+			return nil, true, fmt.Errorf("rule signedOff takes no arguments or \"any\"")
+		}
+		return &SignedOffRule{Any: len(args) == 1}, true, nil
+	case "allowtrailers":
+		rule = &AllowTrailersRule{Tokens: args}
+	case "spelling":
+		rule = &SpellingRule{Dictionaries: args}
+	case "minwords":
+		minWords, err := parseMinWords(args)
+		return minWords, true, err
+	default:
+		return nil, false, nil
+	}
+	// Validating empty text checks the arguments without checking any text.
+	if err := rule.Validate(""); err != nil {
+		return nil, true, err
+	}
+	return rule, true, nil
+}
+
+// patternRules take a single regular expression argument, which may contain
+// commas, as in "branchTicket([A-Z]{2,5}-\d+)".
+var patternRules = []string{"branchticket"}
+
+// parseRuleSpec splits a rule specification such as "allowScripts(Latin, Greek)"
+// into the rule name and its arguments.
+func parseRuleSpec(spec string) (string, []string, error) {
+	spec = strings.TrimSpace(spec)
+	open := strings.IndexByte(spec, '(')
+	if open < 0 {
+		return spec, nil, nil
+	}
+	if !strings.HasSuffix(spec, ")") {
+		return "", nil, fmt.Errorf("invalid rule: %s", spec)
+	}
+	name, raw := strings.TrimSpace(spec[:open]), strings.TrimSpace(spec[open+1:len(spec)-1])
+	if slices.Contains(patternRules, strings.ToLower(name)) {
+		if raw == "" {
+			return name, nil, nil
+		}
+		return name, []string{raw}, nil
+	}
+	var args []string
+	for _, arg := range strings.Split(raw, ",") {
+		if arg = strings.TrimSpace(arg); arg != "" {
+			args = append(args, arg)
+		}
+	}
+	return name, args, nil
+}
+
 // NoCyrillicRule prevents Cyrillic characters
 type NoCyrillicRule struct{}
 
 func (r *NoCyrillicRule) Validate(text string) error {
-	if regexp.MustCompile(`[\p{Cyrillic}]`).MatchString(text) {
-		return fmt.Errorf("text contains Cyrillic characters")
-	}
-	return nil
+	return (&DenyScriptsRule{Scripts: []string{"Cyrillic"}}).Validate(text)
 }
 
-// NoLatinRule prevents Latin characters
+// NoLatinRule prevents ASCII Latin characters
 type NoLatinRule struct{}
 
 func (r *NoLatinRule) Validate(text string) error {
-	if regexp.MustCompile(`[a-zA-Z]`).MatchString(text) {
-		return fmt.Errorf("text contains Latin characters")
-	}
-	return nil
+	return (&DenyScriptsRule{Scripts: []string{"Latin"}, ASCII: true}).Validate(text)
 }
 
 // NoDigitsRule prevents digits
@@ -90,20 +262,14 @@ func (r *NoDigitsRule) Validate(text string) error {
 type CyrillicOnlyRule struct{}
 
 func (r *CyrillicOnlyRule) Validate(text string) error {
-	if regexp.MustCompile(`[^\p{Cyrillic}\s\p{P}]`).MatchString(text) {
-		return fmt.Errorf("text contains non-Cyrillic characters")
-	}
-	return nil
+	return (&OnlyScriptsRule{Scripts: []string{"Cyrillic"}}).Validate(text)
 }
 
-// LatinOnlyRule allows only Latin characters and basic punctuation
+// LatinOnlyRule allows only ASCII Latin characters and basic punctuation
 type LatinOnlyRule struct{}
 
 func (r *LatinOnlyRule) Validate(text string) error {
-	if regexp.MustCompile(`[^a-zA-Z\s\p{P}]`).MatchString(text) {
-		return fmt.Errorf("text contains non-Latin characters")
-	}
-	return nil
+	return (&OnlyScriptsRule{Scripts: []string{"Latin"}, ASCII: true}).Validate(text)
 }
 
 // DigitsOnlyRule allows only digits and basic punctuation
@@ -116,24 +282,18 @@ func (r *DigitsOnlyRule) Validate(text string) error {
 	return nil
 }
 
-// AllowLatinRule allows Latin characters but doesn't require them
+// AllowLatinRule allows ASCII Latin characters but doesn't require them
 type AllowLatinRule struct{}
 
 func (r *AllowLatinRule) Validate(text string) error {
-	if regexp.MustCompile(`[^a-zA-Z\s\p{P}\d-]`).MatchString(text) {
-		return fmt.Errorf("text contains characters that are not Latin, digits, spaces, or punctuation")
-	}
-	return nil
+	return (&AllowScriptsRule{Scripts: []string{"Latin"}, ASCII: true}).Validate(text)
 }
 
 // AllowCyrillicRule allows Cyrillic characters but doesn't require them
 type AllowCyrillicRule struct{}
 
 func (r *AllowCyrillicRule) Validate(text string) error {
-	if regexp.MustCompile(`[^\p{Cyrillic}\s\p{P}\d-]`).MatchString(text) {
-		return fmt.Errorf("text contains characters that are not Cyrillic, digits, spaces, or punctuation")
-	}
-	return nil
+	return (&AllowScriptsRule{Scripts: []string{"Cyrillic"}}).Validate(text)
 }
 
 // AllowDigitsRule allows digits but doesn't require them
@@ -211,3 +371,21 @@ func (r *NoTrailingPeriodRule) Validate(text string) error {
 	}
 	return nil
 }
+
+// BlankLineAfterHeaderRule requires exactly one blank line between the header
+// and the body of a whole commit message.
+type BlankLineAfterHeaderRule struct{}
+
+func (r *BlankLineAfterHeaderRule) Validate(text string) error {
+	lines := strings.Split(text, "\n")
+	if len(lines) < 2 || strings.TrimSpace(strings.Join(lines[1:], "\n")) == "" {
+		return nil
+	}
+	if strings.TrimSpace(lines[1]) != "" {
+		return fmt.Errorf("header must be followed by a blank line")
+	}
+	if strings.TrimSpace(lines[2]) == "" {
+		return fmt.Errorf("header must be followed by exactly one blank line")
+	}
+	return nil
+}
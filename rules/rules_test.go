@@ -25,6 +25,65 @@ func TestRuleFactory(t *testing.T) {
 		{"valid oneline", "oneline", false},
 		{"valid trailing period", "trailingPeriod", false},
 		{"valid no trailing period", "noTrailingPeriod", false},
+		{"valid lower case", "lowerCase", false},
+		{"valid upper case", "upperCase", false},
+		{"valid sentence case", "sentenceCase", false},
+		{"valid start lower case", "startLowerCase", false},
+		{"valid kebab case", "kebabCase", false},
+		{"valid snake case", "snakeCase", false},
+		{"valid camel case", "camelCase", false},
+		{"valid pascal case", "pascalCase", false},
+		{"valid blank line after header", "blankLineAfterHeader", false},
+		{"valid nfc", "nfc", false},
+		{"valid no mixed script words", "noMixedScriptWords", false},
+		{"valid no confusables", "noConfusables", false},
+		{"valid no wrong layout", "noWrongLayout", false},
+		{"valid no invisible chars", "noInvisibleChars", false},
+		{"valid no crlf", "noCRLF", false},
+		{"valid no bom", "noBOM", false},
+		{"valid valid utf8", "validUTF8", false},
+		{"valid allow scripts", "allowScripts(Latin, Greek)", false},
+		{"valid only scripts", "onlyScripts(han)", false},
+		{"valid deny scripts", "denyScripts(Cyrillic)", false},
+		{"scripts rule without scripts", "allowScripts()", true},
+		{"unknown script", "denyScripts(Klingon)", true},
+		{"unclosed arguments", "denyScripts(Cyrillic", true},
+		{"valid imperative", "imperative", false},
+		{"valid russian imperative", "imperative(ru)", false},
+		{"unsupported imperative language", "imperative(fr)", true},
+		{"valid deny words", "denyWords(wip, asdf)", false},
+		{"deny words without words", "denyWords()", true},
+		{"deny words missing file", "denyWords(@testdata/missing.txt)", true},
+		{"valid branch ticket", "branchTicket", false},
+		{"valid branch ticket with pattern", "branchTicket(github)", false},
+		{"branch ticket with invalid pattern", "branchTicket([a-)", true},
+		{"branch ticket with comma in pattern", `branchTicket([A-Z]{2,5}-\d+)`, false},
+		{"valid signed off", "signedOff", false},
+		{"valid signed off by anyone", "signedOff(any)", false},
+		{"signed off with invalid argument", "signedOff(author)", true},
+		{"valid trailers", "validTrailers", false},
+		{"valid no duplicate trailers", "noDuplicateTrailers", false},
+		{"valid no misplaced trailers", "noMisplacedTrailers", false},
+		{"valid allow trailers", "allowTrailers(Signed-off-by, Refs)", false},
+		{"allow trailers without tokens", "allowTrailers()", true},
+		{"valid spelling", "spelling", false},
+		{"valid spelling with dictionaries", "spelling(en, ru, @testdata/words.txt)", false},
+		{"spelling with unknown dictionary", "spelling(fr)", true},
+		{"valid min words", "minWords(3)", false},
+		{"min words without count", "minWords()", true},
+		{"min words with invalid count", "minWords(0)", true},
+		{"valid meaningful", "meaningful", false},
+		{"valid not empty", "notEmpty", false},
+		{"valid empty", "empty", false},
+		{"valid reverts commit", "revertsCommit", false},
+		{"valid no fixup", "noFixup", false},
+		{"valid require gitmoji", "requireGitmoji", false},
+		{"valid no gitmoji", "noGitmoji", false},
+		{"valid gitmoji type", "gitmojiType", false},
+		{"valid gitmoji unicode", "gitmojiUnicode", false},
+		{"valid gitmoji shortcode", "gitmojiShortcode", false},
+		{"arguments for plain rule", "noCyrillic(Latin)", true},
+		{"valid nfkd", "NFKD", false},
 		{"invalid rule", "nonexistent", true},
 		// Case insensitivity tests
 		{"uppercase rule", "NOCYRILLIC", false},
@@ -72,6 +131,9 @@ func TestNoLatinRule(t *testing.T) {
 		{"with latin", "привет hello", true},
 		{"latin only", "hello", true},
 		{"mixed", "hello привет 123", true},
+		// Only ASCII letters count as Latin.
+		{"accented latin", "café", true},
+		{"non-ascii latin only", "é", false},
 	}
 
 	runRuleTests(t, "NoLatinRule", rule, tests)
@@ -126,6 +188,7 @@ func TestLatinOnlyRule(t *testing.T) {
 		{"with cyrillic", "Hello привет", true},
 		{"with digits", "Hello 123", true},
 		{"mixed", "Hello привет 123", true},
+		{"accented latin", "Café", true},
 	}
 
 	runRuleTests(t, "LatinOnlyRule", rule, tests)
@@ -164,6 +227,13 @@ func TestAllowLatinRule(t *testing.T) {
 		{"digits only", "123", false},
 		{"with cyrillic", "Hello привет", true},
 		{"cyrillic only", "привет", true},
+		// The rule is limited to ASCII letters, digits and whitespace; use
+		// allowScripts(Latin) for any Latin letter and Unicode digits.
+		{"accented latin", "café", true},
+		{"sharp s", "straße", true},
+		{"no-break space", "Hello\u00a0world", true},
+		{"arabic-indic digits", "v١٢٣", true},
+		{"zero width joiner", "Hello\u200dworld", true},
 	}
 
 	runRuleTests(t, "AllowLatinRule", rule, tests)
@@ -346,6 +416,25 @@ func TestNoTrailingPeriodRule(t *testing.T) {
 	runRuleTests(t, "NoTrailingPeriodRule", rule, tests)
 }
 
+func TestBlankLineAfterHeaderRule(t *testing.T) {
+	rule := &BlankLineAfterHeaderRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"header only", "feat: add x", false},
+		{"header with trailing newline", "feat: add x\n", false},
+		{"header with body", "feat: add x\n\nBody text", false},
+		{"header with indented body", "feat: add x\n\n    code()", false},
+		{"body directly after header", "feat: add x\nBody text", true},
+		{"two blank lines", "feat: add x\n\n\nBody text", true},
+		{"whitespace-only separator", "feat: add x\n  \nBody text", false},
+	}
+
+	runRuleTests(t, "BlankLineAfterHeaderRule", rule, tests)
+}
+
 // Helper function to run rule tests
 func runRuleTests(t *testing.T, ruleName string, rule Rule, tests []struct {
 	name    string
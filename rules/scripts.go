@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// scriptTables resolves Unicode script names such as "Greek" or "han",
+// case-insensitively, to their canonical names and range tables.
+func scriptTables(rule string, names []string) ([]string, []*unicode.RangeTable, error) {
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("rule %s requires at least one script", rule)
+	}
+	scripts := make([]string, 0, len(names))
+	tables := make([]*unicode.RangeTable, 0, len(names))
+	for _, name := range names {
+		script, table := lookupScript(name)
+		if table == nil {
+			return nil, nil, fmt.Errorf("unknown script: %s", name)
+		}
+		scripts = append(scripts, script)
+		tables = append(tables, table)
+	}
+	return scripts, tables, nil
+}
+
+func lookupScript(name string) (string, *unicode.RangeTable) {
+	if table, ok := unicode.Scripts[name]; ok {
+		return name, table
+	}
+	for script, table := range unicode.Scripts {
+		if strings.EqualFold(script, name) {
+			return script, table
+		}
+	}
+	return "", nil
+}
+
+// scriptCharacterAllowed reports whether char is in one of the tables, or is
+// a space, punctuation or combining mark, which every script uses. Digits are
+// allowed only with allowDigits. With ascii, every character other than
+// punctuation must also be ASCII.
+func scriptCharacterAllowed(char rune, tables []*unicode.RangeTable, allowDigits, ascii bool) bool {
+	switch {
+	case unicode.IsPunct(char):
+		return true
+	case ascii && char > unicode.MaxASCII:
+		return false
+	case unicode.IsSpace(char), unicode.Is(unicode.Inherited, char):
+		return true
+	case unicode.IsDigit(char):
+		return allowDigits
+	}
+	return unicode.In(char, tables...)
+}
+
+// AllowScriptsRule allows characters of the listed Unicode scripts, digits,
+// spaces and punctuation, but doesn't require any of them.
+type AllowScriptsRule struct {
+	Scripts []string
+	// ASCII only allows ASCII characters besides punctuation, e.g. only
+	// a-z and A-Z of the Latin script.
+	ASCII bool
+}
+
+func (r *AllowScriptsRule) Validate(text string) error {
+	names, tables, err := scriptTables("allowScripts", r.Scripts)
+	if err != nil {
+		return err
+	}
+	for _, char := range text {
+		if !scriptCharacterAllowed(char, tables, true, r.ASCII) {
+			return fmt.Errorf("text contains characters that are not %s, digits, spaces, or punctuation", strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// OnlyScriptsRule allows only characters of the listed Unicode scripts,
+// spaces and punctuation.
+type OnlyScriptsRule struct {
+	Scripts []string
+	// ASCII only allows ASCII characters besides punctuation.
+	ASCII bool
+}
+
+func (r *OnlyScriptsRule) Validate(text string) error {
+	names, tables, err := scriptTables("onlyScripts", r.Scripts)
+	if err != nil {
+		return err
+	}
+	for _, char := range text {
+		if scriptCharacterAllowed(char, tables, false, r.ASCII) {
+			continue
+		}
+		if len(names) == 1 {
+			return fmt.Errorf("text contains non-%s characters", names[0])
+		}
+		return fmt.Errorf("text contains characters that are not %s, spaces, or punctuation", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// DenyScriptsRule prevents characters of the listed Unicode scripts.
+type DenyScriptsRule struct {
+	Scripts []string
+	// ASCII only prevents the ASCII characters of the scripts.
+	ASCII bool
+}
+
+func (r *DenyScriptsRule) Validate(text string) error {
+	names, tables, err := scriptTables("denyScripts", r.Scripts)
+	if err != nil {
+		return err
+	}
+	for _, char := range text {
+		if r.ASCII && char > unicode.MaxASCII {
+			continue
+		}
+		for i, table := range tables {
+			if unicode.Is(table, char) {
+				return fmt.Errorf("text contains %s characters", names[i])
+			}
+		}
+	}
+	return nil
+}
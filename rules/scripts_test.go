@@ -0,0 +1,109 @@
+package rules
+
+import "testing"
+
+func TestAllowScriptsRule(t *testing.T) {
+	rule := &AllowScriptsRule{Scripts: []string{"Latin", "Greek"}}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"latin", "Hello", false},
+		{"greek", "Γειά σου", false},
+		{"mixed allowed scripts", "Hello κόσμε", false},
+		{"with digits and punctuation", "Hello, 123!", false},
+		{"with cyrillic", "Hello мир", true},
+		{"with han", "Hello 世界", true},
+		{"empty string", "", false},
+		// Unlike allowLatin, script rules accept any letter of the script,
+		// Unicode digits and spaces, and combining marks.
+		{"accented latin", "café straße", false},
+		{"no-break space", "Hello\u00a0world", false},
+		{"arabic-indic digits", "v١٢٣", false},
+		{"combining mark", "cafe\u0301", false},
+	}
+
+	runRuleTests(t, "AllowScriptsRule", rule, tests)
+}
+
+func TestOnlyScriptsRule(t *testing.T) {
+	rule := &OnlyScriptsRule{Scripts: []string{"Hangul"}}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"hangul", "안녕하세요", false},
+		{"hangul with punctuation", "안녕, 세상!", false},
+		{"with digits", "안녕 123", true},
+		{"with latin", "안녕 hello", true},
+	}
+
+	runRuleTests(t, "OnlyScriptsRule", rule, tests)
+}
+
+func TestDenyScriptsRule(t *testing.T) {
+	rule := &DenyScriptsRule{Scripts: []string{"Arabic", "Hebrew"}}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"latin", "Hello", false},
+		{"cyrillic", "привет", false},
+		{"arabic", "مرحبا", true},
+		{"hebrew", "שלום", true},
+	}
+
+	runRuleTests(t, "DenyScriptsRule", rule, tests)
+}
+
+func TestScriptsRulesASCII(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		text    string
+		wantErr bool
+	}{
+		{"allow ascii latin", &AllowScriptsRule{Scripts: []string{"Latin"}, ASCII: true}, "Fix bug 42, again!", false},
+		{"allow accented latin", &AllowScriptsRule{Scripts: []string{"Latin"}, ASCII: true}, "café", true},
+		{"allow unicode punctuation", &AllowScriptsRule{Scripts: []string{"Latin"}, ASCII: true}, "«Fix» — now", false},
+		{"allow no-break space", &AllowScriptsRule{Scripts: []string{"Latin"}, ASCII: true}, "Fix\u00a0bug", true},
+		{"only combining mark", &OnlyScriptsRule{Scripts: []string{"Latin"}, ASCII: true}, "cafe\u0301", true},
+		{"deny ascii latin", &DenyScriptsRule{Scripts: []string{"Latin"}, ASCII: true}, "привет hello", true},
+		{"deny ignores accented latin", &DenyScriptsRule{Scripts: []string{"Latin"}, ASCII: true}, "é", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(tt.text); (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScriptsRuleMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		text string
+		want string
+	}{
+		{"deny", &DenyScriptsRule{Scripts: []string{"cyrillic"}}, "мир", "text contains Cyrillic characters"},
+		{"only one script", &OnlyScriptsRule{Scripts: []string{"Latin"}}, "мир", "text contains non-Latin characters"},
+		{"only several scripts", &OnlyScriptsRule{Scripts: []string{"Latin", "Greek"}}, "мир", "text contains characters that are not Latin, Greek, spaces, or punctuation"},
+		{"allow", &AllowScriptsRule{Scripts: []string{"Han"}}, "мир", "text contains characters that are not Han, digits, spaces, or punctuation"},
+		{"unknown script", &AllowScriptsRule{Scripts: []string{"Elvish"}}, "", "unknown script: Elvish"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.text)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var identPattern = regexp.MustCompile(`^(.*?)\s*<([^<>]*)>$`)
+
+// SignedOffRule requires a Developer Certificate of Origin sign-off, a
+// "Signed-off-by: Name <email>" trailer in the last paragraph of a whole
+// commit message. Unless Any is set, the sign-off must match the commit
+// author, when the author is known.
+type SignedOffRule struct {
+	Any bool
+}
+
+func (r *SignedOffRule) Validate(text string) error {
+	return r.ValidateWithContext(text, Context{})
+}
+
+func (r *SignedOffRule) ValidateWithContext(text string, ctx Context) error {
+	signOffs := findSignOffs(text)
+	if len(signOffs) == 0 {
+		return fmt.Errorf("message must be signed off with a Signed-off-by trailer")
+	}
+	if r.Any || ctx.Author == "" {
+		return nil
+	}
+	for _, signOff := range signOffs {
+		if sameIdent(signOff, ctx.Author) {
+			return nil
+		}
+	}
+	return fmt.Errorf("message must be signed off by the author %s, got %s", ctx.Author, strings.Join(signOffs, ", "))
+}
+
+// FixWithContext appends a sign-off by the author unless the message already
+// has the sign-off it needs.
+func (r *SignedOffRule) FixWithContext(text string, ctx Context) string {
+	if ctx.Author == "" || r.ValidateWithContext(text, ctx) == nil {
+		return text
+	}
+	return appendTrailer(text, "Signed-off-by: "+ctx.Author)
+}
+
+// findSignOffs returns the identities of the sign-offs in the trailer block.
+func findSignOffs(text string) []string {
+	var signOffs []string
+	block, _ := splitTrailers(text)
+	for _, t := range block {
+		if strings.EqualFold(t.token, "Signed-off-by") && t.value != "" {
+			signOffs = append(signOffs, t.value)
+		}
+	}
+	return signOffs
+}
+
+// sameIdent compares "Name <email>" identities, ignoring the case of the email.
+func sameIdent(a, b string) bool {
+	matchA, matchB := identPattern.FindStringSubmatch(a), identPattern.FindStringSubmatch(b)
+	if matchA == nil || matchB == nil {
+		return a == b
+	}
+	return matchA[1] == matchB[1] && strings.EqualFold(matchA[2], matchB[2])
+}
+
+// appendTrailer adds a trailer to the trailer block ending text, or starts a
+// new trailer block, using the line endings of text.
+func appendTrailer(text, trailer string) string {
+	trimmed := strings.TrimRight(text, " \t\r\n")
+	suffix := text[len(trimmed):]
+	newline := "\n"
+	if strings.Contains(text, "\r\n") {
+		newline = "\r\n"
+	}
+	lines := strings.Split(strings.ReplaceAll(trimmed, "\r\n", "\n"), "\n")
+	// The body starts after the header line.
+	if body := lines[1:]; TrailerBlockStart(body) < len(body) {
+		return trimmed + newline + trailer + suffix
+	}
+	return trimmed + newline + newline + trailer + suffix
+}
@@ -0,0 +1,68 @@
+package rules
+
+import "testing"
+
+func TestSignedOffRule(t *testing.T) {
+	const author = "Jane Doe <jane@example.com>"
+	tests := []struct {
+		name    string
+		any     bool
+		author  string
+		text    string
+		wantErr bool
+	}{
+		{"matching sign-off", false, author, "feat: add x\n\nSigned-off-by: Jane Doe <jane@example.com>", false},
+		{"email case differs", false, author, "feat: add x\n\nSigned-off-by: Jane Doe <Jane@Example.com>\n", false},
+		{"one of several sign-offs", false, author, "feat: add x\n\nSigned-off-by: John Roe <john@example.com>\nSigned-off-by: Jane Doe <jane@example.com>", false},
+		{"other sign-off", false, author, "feat: add x\n\nSigned-off-by: John Roe <john@example.com>", true},
+		{"any sign-off", true, author, "feat: add x\n\nSigned-off-by: John Roe <john@example.com>", false},
+		{"unknown author", false, "", "feat: add x\n\nSigned-off-by: John Roe <john@example.com>", false},
+		{"missing sign-off", false, author, "feat: add x\n\nBody text.", true},
+		{"missing sign-off with any", true, author, "feat: add x", true},
+		{"sign-off not in last paragraph", false, author, "feat: add x\n\nSigned-off-by: Jane Doe <jane@example.com>\n\nMore text.", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&SignedOffRule{Any: tt.any}).ValidateWithContext(tt.text, Context{Author: tt.author})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWithContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignedOffRuleMessage(t *testing.T) {
+	err := (&SignedOffRule{}).ValidateWithContext("feat: add x\n\nSigned-off-by: John Roe <john@example.com>", Context{Author: "Jane Doe <jane@example.com>"})
+	want := "message must be signed off by the author Jane Doe <jane@example.com>, got John Roe <john@example.com>"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateWithContext() error = %v, want %v", err, want)
+	}
+}
+
+func TestSignedOffRuleFix(t *testing.T) {
+	const author = "Jane Doe <jane@example.com>"
+	tests := []struct {
+		name   string
+		author string
+		text   string
+		want   string
+	}{
+		{"header only", author, "feat: add x", "feat: add x\n\nSigned-off-by: Jane Doe <jane@example.com>"},
+		{"body", author, "feat: add x\n\nBody text.\n", "feat: add x\n\nBody text.\n\nSigned-off-by: Jane Doe <jane@example.com>\n"},
+		{"existing trailers", author, "feat: add x\n\nRefs: ABC-1\n", "feat: add x\n\nRefs: ABC-1\nSigned-off-by: Jane Doe <jane@example.com>\n"},
+		{"issue trailer with hash", author, "fix: x\n\nSome body.\n\nFixes #12", "fix: x\n\nSome body.\n\nFixes #12\nSigned-off-by: Jane Doe <jane@example.com>"},
+		{"breaking change trailer", author, "feat!: x\n\nBREAKING CHANGE: drop v1", "feat!: x\n\nBREAKING CHANGE: drop v1\nSigned-off-by: Jane Doe <jane@example.com>"},
+		{"crlf line endings", author, "feat: add x\r\n\r\nBody text.\r\n", "feat: add x\r\n\r\nBody text.\r\n\r\nSigned-off-by: Jane Doe <jane@example.com>\r\n"},
+		{"already signed off", author, "feat: add x\n\nSigned-off-by: Jane Doe <jane@example.com>", "feat: add x\n\nSigned-off-by: Jane Doe <jane@example.com>"},
+		{"unknown author", "", "feat: add x", "feat: add x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&SignedOffRule{}).FixWithContext(tt.text, Context{Author: tt.author}); got != tt.want {
+				t.Errorf("FixWithContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
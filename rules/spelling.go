@@ -0,0 +1,215 @@
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var (
+	//go:embed data/en.aff
+	englishAffixes string
+	//go:embed data/en.dic
+	englishDictionary string
+	//go:embed data/ru.aff
+	russianAffixes string
+	//go:embed data/ru.dic
+	russianDictionary string
+
+	// embeddedDictionaries are expanded on first use, since most runs of the
+	// hook don't check spelling.
+	embeddedDictionaries = map[string]func() map[string]bool{
+		"en": sync.OnceValue(func() map[string]bool { return mustParseDictionary(englishDictionary, englishAffixes) }),
+		"ru": sync.OnceValue(func() map[string]bool { return mustParseDictionary(russianDictionary, russianAffixes) }),
+	}
+)
+
+func mustParseDictionary(dic, aff string) map[string]bool {
+	affixes, err := parseAffixes(aff)
+	if err != nil {
+		panic(err)
+	}
+	return parseDictionary(dic, affixes)
+}
+
+var (
+	codeSpanPattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+	urlPattern      = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+|www\.\S+`)
+)
+
+// SpellingRule reports misspelled words using the embedded English and
+// Russian dictionaries, or the ones listed in Dictionaries: "en", "ru", the
+// path of a Hunspell .dic file with an .aff file next to it, or '@' followed
+// by the path of a custom word list with one word per line. Dictionaries are
+// read once per rule, on first use. Code in backticks, URLs, paths,
+// identifiers, acronyms and the words of the scope are not checked.
+type SpellingRule struct {
+	Dictionaries []string
+
+	words []map[string]bool
+}
+
+func (r *SpellingRule) Validate(text string) error {
+	return r.ValidateWithContext(text, Context{})
+}
+
+func (r *SpellingRule) ValidateWithContext(text string, ctx Context) error {
+	if r.words == nil {
+		words, err := loadDictionaries(r.Dictionaries)
+		if err != nil {
+			return err
+		}
+		r.words = words
+	}
+	dictionaries := r.words
+	ignored := map[string]bool{}
+	for _, w := range identifierWords(ctx.Scope) {
+		ignored[strings.ToLower(w)] = true
+	}
+	for _, w := range spellingWords(text) {
+		if ignored[strings.ToLower(w)] || isSpelledCorrectly(w, dictionaries) {
+			continue
+		}
+		if suggestion := suggestSpelling(w, dictionaries); suggestion != "" {
+			return fmt.Errorf("text contains misspelled word %q, did you mean %q?", w, suggestion)
+		}
+		return fmt.Errorf("text contains misspelled word %q", w)
+	}
+	return nil
+}
+
+func loadDictionaries(names []string) ([]map[string]bool, error) {
+	if len(names) == 0 {
+		names = []string{"en", "ru"}
+	}
+	var dictionaries []map[string]bool
+	for _, name := range names {
+		dictionary, err := loadDictionary(name)
+		if err != nil {
+			return nil, err
+		}
+		dictionaries = append(dictionaries, dictionary)
+	}
+	return dictionaries, nil
+}
+
+func loadDictionary(name string) (map[string]bool, error) {
+	if embedded, ok := embeddedDictionaries[strings.ToLower(name)]; ok {
+		return embedded(), nil
+	}
+	if path, ok := strings.CutPrefix(name, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading word list: %v", err)
+		}
+		return parseWordList(string(data)), nil
+	}
+	if base, ok := strings.CutSuffix(name, ".dic"); ok {
+		dic, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("reading dictionary: %v", err)
+		}
+		aff, err := os.ReadFile(base + ".aff")
+		if err != nil {
+			return nil, fmt.Errorf("reading dictionary: %v", err)
+		}
+		affixes, err := parseAffixes(string(aff))
+		if err != nil {
+			return nil, err
+		}
+		return parseDictionary(string(dic), affixes), nil
+	}
+	return nil, fmt.Errorf("unknown dictionary %s: expected en, ru, a .dic file or @path of a word list", name)
+}
+
+// spellingWords returns the words of text worth spell-checking.
+func spellingWords(text string) []string {
+	text = codeSpanPattern.ReplaceAllString(text, " ")
+	text = urlPattern.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(text, "’", "'")
+	var words []string
+	for _, token := range strings.Fields(text) {
+		token = strings.TrimFunc(token, func(char rune) bool { return !isWordChar(char) })
+		if token == "" || isIdentifierLike(token) {
+			continue
+		}
+		parts := strings.FieldsFunc(token, func(char rune) bool { return !isWordChar(char) && char != '\'' })
+		for _, part := range parts {
+			if part = strings.Trim(part, "'"); len([]rune(part)) > 1 {
+				words = append(words, part)
+			}
+		}
+	}
+	return words
+}
+
+// isIdentifierLike reports whether token is a path, file name, identifier,
+// version or acronym rather than a word: it contains digits or symbols other
+// than hyphens and apostrophes, or upper case letters after the first letter.
+func isIdentifierLike(token string) bool {
+	for i, char := range token {
+		switch {
+		case unicode.IsDigit(char), strings.ContainsRune("/\\_.@=:#<>{}[]()$%&*+~|", char):
+			return true
+		case i > 0 && unicode.IsUpper(char):
+			return true
+		}
+	}
+	return false
+}
+
+func isSpelledCorrectly(word string, dictionaries []map[string]bool) bool {
+	lower := strings.ToLower(word)
+	for _, dictionary := range dictionaries {
+		if dictionary[word] || dictionary[lower] || dictionary[strings.ReplaceAll(lower, "ё", "е")] {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	latinAlphabet    = "abcdefghijklmnopqrstuvwxyz"
+	cyrillicAlphabet = "абвгдеёжзийклмнопрстуфхцчшщъыьэюя"
+)
+
+// suggestSpelling returns a dictionary word one edit away from word,
+// preferring swapped letters, then replaced, removed and added letters.
+func suggestSpelling(word string, dictionaries []map[string]bool) string {
+	runes := []rune(strings.ToLower(word))
+	alphabet := latinAlphabet
+	if unicode.Is(unicode.Cyrillic, runes[0]) {
+		alphabet = cyrillicAlphabet
+	}
+	var candidates []string
+	for i := 0; i+1 < len(runes); i++ {
+		candidate := append([]rune{}, runes...)
+		candidate[i], candidate[i+1] = candidate[i+1], candidate[i]
+		candidates = append(candidates, string(candidate))
+	}
+	for i := range runes {
+		for _, char := range alphabet {
+			candidate := append([]rune{}, runes...)
+			candidate[i] = char
+			candidates = append(candidates, string(candidate))
+		}
+	}
+	for i := range runes {
+		candidates = append(candidates, string(runes[:i])+string(runes[i+1:]))
+	}
+	for i := 0; i <= len(runes); i++ {
+		for _, char := range alphabet {
+			candidates = append(candidates, string(runes[:i])+string(char)+string(runes[i:]))
+		}
+	}
+	for _, candidate := range candidates {
+		if candidate != string(runes) && isSpelledCorrectly(candidate, dictionaries) {
+			return matchCase(candidate, word)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package rules
+
+import "testing"
+
+func TestSpellingRule(t *testing.T) {
+	rule := &SpellingRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"english", "Fix crash when parsing empty config", false},
+		{"russian", "Исправить ошибку в парсере", false},
+		{"mixed languages", "Исправить crash в парсере", false},
+		{"english typo", "Recieve messages", true},
+		{"russian typo", "Исправть ошибку", true},
+		{"code in backticks", "Rename `fooBarr` to `baz`", false},
+		{"fenced code", "Fix parser\n\n```\nxyzzy plugh\n```", false},
+		{"url", "See https://example.com/qwzx for details", false},
+		{"path", "Remove src/qwzx/main.go", false},
+		{"identifier", "Rename HTTPClent and snake_casse", false},
+		{"acronym", "Add QWZX support", false},
+		{"contraction", "Don't fail on missing files", false},
+		{"single letter", "Add x", false},
+		{"empty string", "", false},
+	}
+
+	runRuleTests(t, "SpellingRule", rule, tests)
+}
+
+func TestSpellingRuleMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"swapped letters", "Recieve messages", `text contains misspelled word "Recieve", did you mean "Receive"?`},
+		{"missing letter", "исправть ошибку", `text contains misspelled word "исправть", did you mean "исправить"?`},
+		{"no suggestion", "Add qwzxv", `text contains misspelled word "qwzxv"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&SpellingRule{}).Validate(tt.text)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpellingRuleDictionaries(t *testing.T) {
+	tests := []struct {
+		name         string
+		dictionaries []string
+		text         string
+		wantErr      bool
+	}{
+		{"english only rejects russian", []string{"en"}, "Исправить ошибку", true},
+		{"russian only rejects english", []string{"ru"}, "Fix crash", true},
+		{"custom word list", []string{"en", "@testdata/words.txt"}, "Run kubectl in guardian", false},
+		{"hunspell dictionary", []string{"testdata/custom.dic"}, "frobnicated widgets", false},
+		{"hunspell dictionary typo", []string{"testdata/custom.dic"}, "frobnicates widgetz", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&SpellingRule{Dictionaries: tt.dictionaries}).Validate(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpellingRuleUnknownDictionary(t *testing.T) {
+	for _, dictionaries := range [][]string{{"fr"}, {"testdata/missing.dic"}} {
+		if err := (&SpellingRule{Dictionaries: dictionaries}).Validate("Fix crash"); err == nil {
+			t.Errorf("Validate() with dictionaries %q error = nil, want error", dictionaries)
+		}
+	}
+}
+
+func TestSpellingRuleIgnoresScope(t *testing.T) {
+	rule := &SpellingRule{}
+	if err := rule.ValidateWithContext("Fix kubectl plugin", Context{Scope: "kubectl"}); err != nil {
+		t.Errorf("ValidateWithContext() error = %v, want nil", err)
+	}
+	if err := rule.ValidateWithContext("Fix kubectl plugin", Context{Scope: "api"}); err == nil {
+		t.Error("ValidateWithContext() expected error for a word that is not in the scope")
+	}
+}
@@ -0,0 +1,219 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// trailer is a Git trailer (Conventional Commits footer) at a 1-based line.
+type trailer struct {
+	token string
+	value string
+	line  int
+}
+
+// trailerLinePattern matches a trailer line such as "Refs: #123", "Fixes #12"
+// or "BREAKING CHANGE: drop the v1 API", capturing the token, the ": " or " #"
+// separator and the value.
+var trailerLinePattern = regexp.MustCompile(`^([\w-]+|BREAKING CHANGE)(: | #)(.*)$`)
+
+// identityTrailers hold a person as "Name <email>".
+var identityTrailers = []string{
+	"signed-off-by", "co-authored-by", "reviewed-by", "acked-by",
+	"tested-by", "reported-by", "suggested-by", "helped-by",
+}
+
+// issueTrailers hold issue references.
+var issueTrailers = []string{"fixes", "closes", "resolves", "refs"}
+
+var (
+	identityPattern = regexp.MustCompile(`^[^<>\s][^<>]* <[^<>@\s]+@[^<>@\s]+>$`)
+	// issueValuePattern accepts JIRA, GitHub and GitLab references, issue
+	// URLs and commit hashes, optionally with a quoted subject as in the
+	// Linux kernel's "Fixes: 54a4f0239f2e ("...")".
+	issueValuePattern = regexp.MustCompile(`^(?:[A-Z][A-Z0-9]*[A-Z][A-Z0-9]*-[0-9]+|(?:[\w.-]+(?:/[\w.-]+)*)?#[0-9]+|https?://\S+|[0-9a-f]{7,40}(?: \(".*"\))?)$`)
+)
+
+// ParseTrailerLine splits a trailer line into its token, its ": " or " #"
+// separator and its value. As Git does not treat '#' as a separator, the
+// "Token #value" form is only accepted for issue tokens, as in "Fixes #12",
+// so that body text such as "See #12 for details" is not a trailer.
+func ParseTrailerLine(line string) (token, separator, value string, ok bool) {
+	matches := trailerLinePattern.FindStringSubmatch(line)
+	if matches == nil {
+		return "", "", "", false
+	}
+	if matches[2] == " #" && !slices.Contains(issueTrailers, strings.ToLower(matches[1])) {
+		return "", "", "", false
+	}
+	return matches[1], matches[2], matches[3], true
+}
+
+// parseTrailer parses a trailer line. A "Token #value" trailer keeps the
+// '#' as part of the value, as in "Closes #12".
+func parseTrailer(line string) (trailer, bool) {
+	token, separator, value, ok := ParseTrailerLine(line)
+	if !ok {
+		return trailer{}, false
+	}
+	if separator == " #" {
+		value = "#" + value
+	}
+	return trailer{token: token, value: strings.TrimSpace(value)}, true
+}
+
+// TrailerBlockStart returns the index of the first line of the trailer block
+// of a commit message body, or len(lines) when it has none. The trailer block
+// is the last paragraph when it starts with a trailer and every other line is
+// a trailer or an indented continuation line.
+func TrailerBlockStart(lines []string) int {
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	start := end
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	if start == end || !isTrailerParagraph(lines[start:end]) {
+		return len(lines)
+	}
+	return start
+}
+
+// withoutTrailers returns a body without its trailer block.
+func withoutTrailers(body string) string {
+	lines := strings.Split(body, "\n")
+	return strings.Join(lines[:TrailerBlockStart(lines)], "\n")
+}
+
+// splitTrailers returns the trailer block of a whole commit message and the
+// trailer lines found elsewhere in the body. Only trailers with well-known
+// tokens count outside the block, since any "Word: text" line looks like a
+// trailer.
+func splitTrailers(text string) (block, misplaced []trailer) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	end := len(lines)
+	for end > 1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	// The body starts after the header line.
+	blockStart := 1 + TrailerBlockStart(lines[1:end])
+
+	for i := 1; i < end; i++ {
+		t, ok := parseTrailer(lines[i])
+		if !ok {
+			if i > blockStart && len(block) > 0 {
+				// A continuation line of the previous trailer.
+				block[len(block)-1].value += " " + strings.TrimSpace(lines[i])
+			}
+			continue
+		}
+		t.line = i + 1
+		if i >= blockStart {
+			block = append(block, t)
+		} else if isKnownTrailer(t.token) {
+			misplaced = append(misplaced, t)
+		}
+	}
+	return block, misplaced
+}
+
+// isTrailerParagraph reports whether a paragraph starts with a trailer and
+// every other line is a trailer or an indented continuation line.
+func isTrailerParagraph(lines []string) bool {
+	if _, ok := parseTrailer(lines[0]); !ok {
+		return false
+	}
+	for _, line := range lines[1:] {
+		if _, ok := parseTrailer(line); !ok && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			return false
+		}
+	}
+	return true
+}
+
+func isKnownTrailer(token string) bool {
+	token = strings.ToLower(token)
+	return slices.Contains(identityTrailers, token) || slices.Contains(issueTrailers, token)
+}
+
+// ValidTrailersRule validates the values of well-known trailers in a whole
+// commit message: people, as in Co-authored-by and Reviewed-by, must be
+// "Name <email>", and Fixes, Closes, Resolves and Refs must be issue
+// references, issue URLs or commit hashes.
+type ValidTrailersRule struct{}
+
+func (r *ValidTrailersRule) Validate(text string) error {
+	block, _ := splitTrailers(text)
+	for _, t := range block {
+		token := strings.ToLower(t.token)
+		switch {
+		case slices.Contains(identityTrailers, token):
+			if !identityPattern.MatchString(t.value) {
+				return fmt.Errorf("trailer %s at line %d must be \"Name <email>\", got %q", t.token, t.line, t.value)
+			}
+		case slices.Contains(issueTrailers, token):
+			for _, value := range strings.Split(t.value, ",") {
+				if !issueValuePattern.MatchString(strings.TrimSpace(value)) {
+					return fmt.Errorf("trailer %s at line %d must reference an issue, got %q", t.token, t.line, t.value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// NoDuplicateTrailersRule prevents repeating a trailer with the same token
+// and value.
+type NoDuplicateTrailersRule struct{}
+
+func (r *NoDuplicateTrailersRule) Validate(text string) error {
+	block, _ := splitTrailers(text)
+	seen := map[string]bool{}
+	for _, t := range block {
+		key := strings.ToLower(t.token) + "\x00" + t.value
+		if seen[key] {
+			return fmt.Errorf("trailer %s: %s at line %d is duplicated", t.token, t.value, t.line)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// NoMisplacedTrailersRule prevents well-known trailers outside the trailer
+// block at the end of the message, where Git and hosting platforms ignore
+// them.
+type NoMisplacedTrailersRule struct{}
+
+func (r *NoMisplacedTrailersRule) Validate(text string) error {
+	if _, misplaced := splitTrailers(text); len(misplaced) > 0 {
+		t := misplaced[0]
+		return fmt.Errorf("trailer %s at line %d must be in the last paragraph of the message with the other trailers", t.token, t.line)
+	}
+	return nil
+}
+
+// AllowTrailersRule allows only the listed trailer tokens, compared
+// case-insensitively. BREAKING CHANGE footers are always allowed.
+type AllowTrailersRule struct {
+	Tokens []string
+}
+
+func (r *AllowTrailersRule) Validate(text string) error {
+	if len(r.Tokens) == 0 {
+		return fmt.Errorf("rule allowTrailers requires at least one trailer token")
+	}
+	block, _ := splitTrailers(text)
+	for _, t := range block {
+		if t.token == "BREAKING CHANGE" || t.token == "BREAKING-CHANGE" {
+			continue
+		}
+		if !slices.ContainsFunc(r.Tokens, func(token string) bool { return strings.EqualFold(token, t.token) }) {
+			return fmt.Errorf("trailer %s at line %d is not allowed, allowed trailers are %s", t.token, t.line, strings.Join(r.Tokens, ", "))
+		}
+	}
+	return nil
+}
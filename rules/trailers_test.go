@@ -0,0 +1,90 @@
+package rules
+
+import "testing"
+
+func TestValidTrailersRule(t *testing.T) {
+	rule := &ValidTrailersRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"no trailers", "feat: add x\n\nBody text.", false},
+		{"header only", "feat: add x", false},
+		{"valid co-author", "feat: add x\n\nCo-authored-by: Jane Doe <jane@example.com>", false},
+		{"co-author without email", "feat: add x\n\nCo-authored-by: Jane Doe", true},
+		{"co-author without name", "feat: add x\n\nCo-authored-by: <jane@example.com>", true},
+		{"co-author with broken brackets", "feat: add x\n\nCo-authored-by: Jane Doe jane@example.com>", true},
+		{"reviewer in lower case token", "feat: add x\n\nreviewed-by: Jane Doe", true},
+		{"valid github fixes", "fix: crash\n\nFixes #12", false},
+		{"valid jira closes", "fix: crash\n\nCloses: ABC-12, ABC-13", false},
+		{"valid gitlab refs", "fix: crash\n\nRefs: group/proj#12", false},
+		{"valid issue url", "fix: crash\n\nFixes: https://github.com/o/r/issues/12", false},
+		{"valid kernel fixes", "fix: crash\n\nFixes: 54a4f0239f2e (\"net: fix crash\")", false},
+		{"fixes without reference", "fix: crash\n\nFixes: the crash", true},
+		{"unknown token is not validated", "fix: crash\n\nX-Custom: anything", false},
+		{"trailer-like body line is not validated", "fix: crash\n\nCo-authored-by: someone\nand more text", false},
+	}
+
+	runRuleTests(t, "ValidTrailersRule", rule, tests)
+
+	err := rule.Validate("feat: add x\n\nBody.\n\nCo-authored-by: Jane Doe")
+	want := `trailer Co-authored-by at line 5 must be "Name <email>", got "Jane Doe"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
+
+func TestNoDuplicateTrailersRule(t *testing.T) {
+	rule := &NoDuplicateTrailersRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"distinct trailers", "feat: add x\n\nCo-authored-by: A <a@example.com>\nCo-authored-by: B <b@example.com>", false},
+		{"duplicate trailer", "feat: add x\n\nCo-authored-by: A <a@example.com>\nco-authored-by: A <a@example.com>", true},
+		{"header only", "feat: add x", false},
+	}
+
+	runRuleTests(t, "NoDuplicateTrailersRule", rule, tests)
+}
+
+func TestNoMisplacedTrailersRule(t *testing.T) {
+	rule := &NoMisplacedTrailersRule{}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"trailers at the end", "feat: add x\n\nBody.\n\nSigned-off-by: A <a@example.com>", false},
+		{"trailer in the middle", "feat: add x\n\nCo-authored-by: A <a@example.com>\n\nMore body text.", true},
+		{"trailer mixed into the last paragraph", "feat: add x\n\nBody text.\nCo-authored-by: A <a@example.com>", true},
+		{"unknown token in the middle", "feat: add x\n\nNote: this is prose.\n\nMore body text.", false},
+		{"header only", "feat: add x", false},
+	}
+
+	runRuleTests(t, "NoMisplacedTrailersRule", rule, tests)
+
+	err := rule.Validate("feat: add x\n\nCo-authored-by: A <a@example.com>\n\nMore body text.")
+	want := "trailer Co-authored-by at line 3 must be in the last paragraph of the message with the other trailers"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
+
+func TestAllowTrailersRule(t *testing.T) {
+	rule := &AllowTrailersRule{Tokens: []string{"Signed-off-by", "Refs"}}
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"allowed trailers", "feat: add x\n\nrefs: ABC-1\nSigned-off-by: A <a@example.com>", false},
+		{"unknown trailer", "feat: add x\n\nChange-Id: I1234", true},
+		{"breaking change", "feat!: add x\n\nBREAKING CHANGE: drop v1", false},
+		{"header only", "feat: add x", false},
+	}
+
+	runRuleTests(t, "AllowTrailersRule", rule, tests)
+}
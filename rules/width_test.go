@@ -0,0 +1,28 @@
+package rules
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"latin", "hello", 5},
+		{"cyrillic", "привет", 6},
+		{"han", "漢字", 4},
+		{"hangul", "한국", 4},
+		{"fullwidth", "ＡＢ", 4},
+		{"emoji", "🐛", 2},
+		{"combining mark", "e\u0301", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayWidth(tt.text); got != tt.want {
+				t.Errorf("DisplayWidth(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}